@@ -0,0 +1,469 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/senzing-garage/playground/authentication"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicAudit appends Records to File, one JSON object per line.  The file is only
+ever appended to; when a record would take it past MaxSize bytes, it is renamed
+File.1, File.1 is renamed File.2, and so on, keeping MaxBackups rotated files.
+Until Open is called, nothing is recorded.
+Every method of a nil *BasicAudit is a no-op that returns its input unchanged,
+so auditing can be disabled by passing nil.
+*/
+type BasicAudit struct {
+	File       string
+	logger     logging.Logging
+	MaxBackups int
+	MaxSize    int64
+	mutex      sync.Mutex
+	output     *os.File
+	size       int64
+}
+
+// statusRecorder remembers the status code of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The Close method closes File.  Nothing more is recorded.
+func (audit *BasicAudit) Close() error {
+	if audit == nil {
+		return nil
+	}
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	if audit.output == nil {
+		return nil
+	}
+	err := audit.output.Close()
+	audit.output = nil
+	return err
+}
+
+/*
+The GrpcServerOptions method returns options that record each call a gRPC server
+handles to an audited method, once it has been handled.  The caller is the Identity
+authentication added to the context of the call, so these options must follow
+those of authentication.BasicAuthentication.
+
+Input
+  - isAudited: Reports whether a method, given its full name, is recorded.
+
+Output
+  - Options for grpc.NewServer.
+*/
+func (audit *BasicAudit) GrpcServerOptions(isAudited func(fullMethod string) bool) []grpc.ServerOption {
+	if audit == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			response, err := handler(ctx, request)
+			if isAudited(info.FullMethod) {
+				audit.recordCall(ctx, info.FullMethod, err)
+			}
+			return response, err
+		}),
+		grpc.ChainStreamInterceptor(func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := handler(server, stream)
+			if isAudited(info.FullMethod) {
+				audit.recordCall(stream.Context(), info.FullMethod, err)
+			}
+			return err
+		}),
+	}
+}
+
+/*
+The Handler method records each audited request, once a handler has served it.
+The operation recorded is the method and path of the request.
+
+Input
+  - handler: The handler to audit.
+  - getService: Returns the sub-service an audited request is for, or "" if the request is not recorded.
+
+Output
+  - The audited handler.
+*/
+func (audit *BasicAudit) Handler(handler http.Handler, getService func(r *http.Request) string) http.Handler {
+	if audit == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		service := getService(r)
+		if len(service) == 0 {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(recorder, r)
+		audit.RecordRequest(r, service, r.Method+" "+r.URL.Path, strconv.Itoa(recorder.statusCode))
+	})
+}
+
+/*
+The Open method opens File for appending, creating it and its directory if need be.
+
+Output
+  - ErrMissingFile if File is not set, otherwise any error opening it.
+*/
+func (audit *BasicAudit) Open() error {
+	if audit == nil {
+		return nil
+	}
+	if len(audit.File) == 0 {
+		return ErrMissingFile
+	}
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	err := os.MkdirAll(filepath.Dir(audit.File), 0o750)
+	if err != nil {
+		return err
+	}
+	return audit.openFile()
+}
+
+/*
+The Query method reads the Records of File, and of its rotated files, that a Filter selects.
+
+Input
+  - filter: Which records to return.
+
+Output
+  - The records, oldest first.
+*/
+func (audit *BasicAudit) Query(filter Filter) ([]Record, error) {
+	if audit == nil {
+		return nil, nil
+	}
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	return Read(audit.File, filter)
+}
+
+/*
+The Record method appends a Record to File.  A record without a Time is given the current time.
+Records that cannot be written are logged.
+
+Input
+  - record: The operation to record.
+*/
+func (audit *BasicAudit) Record(record Record) {
+	if audit == nil {
+		return
+	}
+	if record.Time.IsZero() {
+		record.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		audit.log(4001, audit.File, err)
+		return
+	}
+	line = append(line, '\n')
+	audit.mutex.Lock()
+	defer audit.mutex.Unlock()
+	if audit.output == nil {
+		return
+	}
+	if audit.size > 0 && audit.size+int64(len(line)) > audit.getMaxSize() {
+		err = audit.rotate()
+		if err != nil {
+			audit.log(4002, audit.File, err)
+		}
+		if audit.output == nil {
+			return
+		}
+	}
+	count, err := audit.output.Write(line)
+	audit.size += int64(count)
+	if err != nil {
+		audit.log(4001, audit.File, err)
+	}
+}
+
+/*
+The RecordRequest method records an operation done through an HTTP request,
+by the Identity authentication added to the context of the request, if any.
+
+Input
+  - r: The request.
+  - service: The sub-service that served the request.  Example: "senzing-rest-api".
+  - operation: What was done.  Example: OperationSessionStart.
+  - status: The outcome.  Example: "200".
+*/
+func (audit *BasicAudit) RecordRequest(r *http.Request, service string, operation string, status string) {
+	if audit == nil {
+		return
+	}
+	record := Record{
+		Operation:     operation,
+		RemoteAddress: r.RemoteAddr,
+		Service:       service,
+		Status:        status,
+	}
+	if identity := authentication.IdentityFromContext(r.Context()); identity != nil {
+		record.Role = identity.Role
+		record.User = identity.Name
+	}
+	audit.Record(record)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Read function reads the Records of an audit log, and of its rotated files,
+that a Filter selects.
+
+Input
+  - filename: Path of the audit log.
+  - filter: Which records to return.
+
+Output
+  - The records, oldest first.  An error if neither the audit log nor any rotated file exists.
+*/
+func Read(filename string, filter Filter) ([]Record, error) {
+	backups := getBackups(filename)
+	result := []Record{}
+	for _, name := range append(backups, filename) {
+		records, err := readFile(name, filter)
+		if errors.Is(err, os.ErrNotExist) && len(backups) > 0 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, records...)
+	}
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[len(result)-filter.Limit:]
+	}
+	return result, nil
+}
+
+/*
+The ParseSince function parses the start of a time range, either as a time or as how long ago.
+
+Input
+  - value: An RFC 3339 time, or a duration.  Example: "2024-01-01T00:00:00Z" or "24h".  Empty for no start.
+  - now: The time durations are counted back from.
+
+Output
+  - The time.  The zero time for an empty value.
+*/
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is neither an RFC 3339 time nor a duration", ErrInvalidSince, value)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// --- Logging ----------------------------------------------------------------
+
+// Get the Logger singleton.
+func (audit *BasicAudit) getLogger() logging.Logging {
+	var err error
+	if audit.logger == nil {
+		options := []interface{}{
+			logging.OptionCallerSkip{Value: 3},
+			logging.OptionMessageFields{Value: []string{"id", "text", "reason", "errors", "details"}},
+		}
+		audit.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return audit.logger
+}
+
+// Log message.
+func (audit *BasicAudit) log(messageNumber int, details ...interface{}) {
+	audit.getLogger().Log(messageNumber, details...)
+}
+
+// --- Files ------------------------------------------------------------------
+
+func (audit *BasicAudit) getMaxBackups() int {
+	if audit.MaxBackups <= 0 {
+		return DefaultMaxBackups
+	}
+	return audit.MaxBackups
+}
+
+func (audit *BasicAudit) getMaxSize() int64 {
+	if audit.MaxSize <= 0 {
+		return DefaultMaxSize
+	}
+	return audit.MaxSize
+}
+
+// Open File for appending.  The caller holds the mutex.
+func (audit *BasicAudit) openFile() error {
+	output, err := os.OpenFile(audit.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := output.Stat()
+	if err != nil {
+		output.Close()
+		return err
+	}
+	audit.output = output
+	audit.size = info.Size()
+	return nil
+}
+
+// Rename File to File.1, after shifting the rotated files up and dropping the oldest, then reopen File.
+// File is reopened even if renaming fails, so records are not lost.  The caller holds the mutex.
+func (audit *BasicAudit) rotate() error {
+	err := audit.output.Close()
+	audit.output = nil
+	for index := audit.getMaxBackups() - 1; index > 0 && err == nil; index-- {
+		err = os.Rename(fmt.Sprintf("%s.%d", audit.File, index), fmt.Sprintf("%s.%d", audit.File, index+1))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = os.Rename(audit.File, audit.File+".1")
+	}
+	return errors.Join(err, audit.openFile())
+}
+
+// --- Calls ------------------------------------------------------------------
+
+// Record a gRPC call, by the Identity in its context, if any.
+func (audit *BasicAudit) recordCall(ctx context.Context, fullMethod string, err error) {
+	record := Record{
+		Operation: fullMethod,
+		Service:   ServiceGrpc,
+		Status:    status.Code(err).String(),
+	}
+	if err != nil {
+		record.Error = status.Convert(err).Message()
+	}
+	if identity := authentication.IdentityFromContext(ctx); identity != nil {
+		record.Role = identity.Role
+		record.User = identity.Name
+	}
+	if caller, isPeer := peer.FromContext(ctx); isPeer && caller.Addr != nil {
+		record.RemoteAddress = caller.Addr.String()
+	}
+	audit.Record(record)
+}
+
+// --- statusRecorder ---------------------------------------------------------
+
+func (recorder *statusRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Rotated files of an audit log, oldest first.
+func getBackups(filename string) []string {
+	matches, _ := filepath.Glob(filename + ".*")
+	numbers := []int{}
+	for _, match := range matches {
+		number, err := strconv.Atoi(strings.TrimPrefix(match, filename+"."))
+		if err == nil && number > 0 {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(numbers)))
+	result := []string{}
+	for _, number := range numbers {
+		result = append(result, fmt.Sprintf("%s.%d", filename, number))
+	}
+	return result
+}
+
+// Whether a Filter selects a Record.
+func matches(filter Filter, record Record) bool {
+	switch {
+	case len(filter.Operation) > 0 && !strings.Contains(strings.ToLower(record.Operation), strings.ToLower(filter.Operation)):
+		return false
+	case len(filter.Service) > 0 && record.Service != filter.Service:
+		return false
+	case !filter.Since.IsZero() && record.Time.Before(filter.Since):
+		return false
+	case len(filter.User) > 0 && record.User != filter.User:
+		return false
+	}
+	return true
+}
+
+// The Records of one file that a Filter selects.
+func readFile(filename string, filter Filter) ([]Record, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	result := []Record{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		record := Record{}
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: line %d: %w", ErrInvalidRecord, filename, lineNumber, err)
+		}
+		if matches(filter, record) {
+			result = append(result, record)
+		}
+	}
+	return result, scanner.Err()
+}
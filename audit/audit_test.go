@@ -0,0 +1,235 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestBasicAudit_Record(test *testing.T) {
+	audit := getTestObject(test)
+	audit.Record(Record{Operation: "/szengine.SzEngine/AddRecord", Service: ServiceGrpc, Status: "OK", User: "alice"})
+	audit.Record(Record{Operation: "DELETE /api/data-sources/TEST/records/1", Service: "senzing-rest-api", Status: "200", User: "bob"})
+	records, err := audit.Query(Filter{})
+	require.NoError(test, err)
+	require.Len(test, records, 2)
+	assert.Equal(test, "alice", records[0].User)
+	assert.False(test, records[0].Time.IsZero())
+	assert.Equal(test, "bob", records[1].User)
+
+	require.NoError(test, audit.Close())
+	audit.Record(Record{Operation: "ignored", Service: ServiceGrpc})
+	require.NoError(test, audit.Open())
+	audit.Record(Record{Operation: "/szdiagnostic.SzDiagnostic/PurgeRepository", Service: ServiceGrpc, User: "alice"})
+	records, err = audit.Query(Filter{})
+	require.NoError(test, err)
+	require.Len(test, records, 3, "records are appended, and none while closed")
+	assert.Equal(test, "/szdiagnostic.SzDiagnostic/PurgeRepository", records[2].Operation)
+}
+
+func TestBasicAudit_Record_rotate(test *testing.T) {
+	audit := getTestObject(test)
+	audit.MaxBackups = 2
+	audit.MaxSize = 250
+	for index := 0; index < 7; index++ {
+		audit.Record(Record{Operation: "/szengine.SzEngine/AddRecord", Service: ServiceGrpc, Status: "OK"})
+	}
+	assert.FileExists(test, audit.File+".1")
+	assert.FileExists(test, audit.File+".2")
+	assert.NoFileExists(test, audit.File+".3")
+	info, err := os.Stat(audit.File)
+	require.NoError(test, err)
+	assert.LessOrEqual(test, info.Size(), int64(250))
+	records, err := audit.Query(Filter{})
+	require.NoError(test, err)
+	assert.Len(test, records, 5, "the oldest records are dropped with the oldest rotated file")
+}
+
+func TestBasicAudit_Handler(test *testing.T) {
+	audit := getTestObject(test)
+	handler := audit.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}), func(r *http.Request) string {
+		if r.Method == http.MethodGet {
+			return ""
+		}
+		return "senzing-rest-api"
+	})
+	identity := &authentication.Identity{Name: "alice", Role: authentication.RoleLoader}
+	request := httptest.NewRequest(http.MethodPost, "/api/data-sources", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request.WithContext(authentication.ContextWithIdentity(request.Context(), identity)))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/data-sources", nil))
+	records, err := audit.Query(Filter{})
+	require.NoError(test, err)
+	require.Len(test, records, 1, "GET requests are not audited")
+	assert.Equal(test, "POST /api/data-sources", records[0].Operation)
+	assert.Equal(test, authentication.RoleLoader, records[0].Role)
+	assert.Equal(test, "senzing-rest-api", records[0].Service)
+	assert.Equal(test, "201", records[0].Status)
+	assert.Equal(test, "alice", records[0].User)
+}
+
+func TestBasicAudit_GrpcServerOptions(test *testing.T) {
+	ctx := context.TODO()
+	audit := getTestObject(test)
+	listener := bufconn.Listen(1024 * 1024)
+	isAudited := true
+	server := grpc.NewServer(audit.GrpcServerOptions(func(fullMethod string) bool {
+		return isAudited && fullMethod == grpc_health_v1.Health_Check_FullMethodName
+	})...)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer connection.Close()
+	client := grpc_health_v1.NewHealthClient(connection)
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err)
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+	require.Error(test, err)
+	isAudited = false
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err)
+
+	records, err := audit.Query(Filter{})
+	require.NoError(test, err)
+	require.Len(test, records, 2)
+	assert.Equal(test, grpc_health_v1.Health_Check_FullMethodName, records[0].Operation)
+	assert.Equal(test, ServiceGrpc, records[0].Service)
+	assert.Equal(test, "OK", records[0].Status)
+	assert.Equal(test, "NotFound", records[1].Status)
+	assert.NotEmpty(test, records[1].Error)
+}
+
+func TestBasicAudit_nil(test *testing.T) {
+	var audit *BasicAudit
+	require.NoError(test, audit.Open())
+	audit.Record(Record{Operation: "ignored"})
+	audit.RecordRequest(httptest.NewRequest(http.MethodGet, "/", nil), ServiceXterm, OperationSessionStart, "")
+	records, err := audit.Query(Filter{})
+	require.NoError(test, err)
+	assert.Empty(test, records)
+	assert.Nil(test, audit.GrpcServerOptions(nil))
+	handler := http.NotFoundHandler()
+	response := httptest.NewRecorder()
+	audit.Handler(handler, nil).ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(test, http.StatusNotFound, response.Code)
+	require.NoError(test, audit.Close())
+}
+
+func TestBasicAudit_Open_missingFile(test *testing.T) {
+	audit := &BasicAudit{}
+	require.ErrorIs(test, audit.Open(), ErrMissingFile)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestRead(test *testing.T) {
+	audit := getTestObject(test)
+	now := time.Now().UTC()
+	audit.Record(Record{Operation: "/szengine.SzEngine/AddRecord", Service: ServiceGrpc, Time: now.Add(-2 * time.Hour), User: "alice"})
+	audit.Record(Record{Operation: "/szengine.SzEngine/DeleteRecord", Service: ServiceGrpc, Time: now.Add(-time.Hour), User: "bob"})
+	audit.Record(Record{Operation: OperationSessionStart, Service: ServiceXterm, Time: now, User: "alice"})
+	testCases := map[string]struct {
+		filter   Filter
+		expected []string
+	}{
+		"all":       {filter: Filter{}, expected: []string{"/szengine.SzEngine/AddRecord", "/szengine.SzEngine/DeleteRecord", OperationSessionStart}},
+		"limit":     {filter: Filter{Limit: 2}, expected: []string{"/szengine.SzEngine/DeleteRecord", OperationSessionStart}},
+		"operation": {filter: Filter{Operation: "deleterecord"}, expected: []string{"/szengine.SzEngine/DeleteRecord"}},
+		"service":   {filter: Filter{Service: ServiceXterm}, expected: []string{OperationSessionStart}},
+		"since":     {filter: Filter{Since: now.Add(-90 * time.Minute)}, expected: []string{"/szengine.SzEngine/DeleteRecord", OperationSessionStart}},
+		"user":      {filter: Filter{User: "alice"}, expected: []string{"/szengine.SzEngine/AddRecord", OperationSessionStart}},
+	}
+	for name, testCase := range testCases {
+		records, err := Read(audit.File, testCase.filter)
+		require.NoError(test, err, name)
+		operations := []string{}
+		for _, record := range records {
+			operations = append(operations, record.Operation)
+		}
+		assert.Equal(test, testCase.expected, operations, name)
+	}
+}
+
+func TestRead_errors(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "audit.log")
+	_, err := Read(filename, Filter{})
+	require.ErrorIs(test, err, os.ErrNotExist)
+	require.NoError(test, os.WriteFile(filename+".1", []byte(`{"operation": "old", "service": "grpc"}`+"\n"), 0o600))
+	records, err := Read(filename, Filter{})
+	require.NoError(test, err, "rotated files are read without the audit log")
+	assert.Len(test, records, 1)
+	require.NoError(test, os.WriteFile(filename, []byte("\n{\"operation\": \"new\"}\nnot JSON\n"), 0o600))
+	_, err = Read(filename, Filter{})
+	require.ErrorIs(test, err, ErrInvalidRecord)
+	require.ErrorContains(test, err, "line 3")
+}
+
+func TestParseSince(test *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	since, err := ParseSince("", now)
+	require.NoError(test, err)
+	assert.True(test, since.IsZero())
+	since, err = ParseSince("24h", now)
+	require.NoError(test, err)
+	assert.Equal(test, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), since)
+	since, err = ParseSince("2023-12-31T12:00:00Z", now)
+	require.NoError(test, err)
+	assert.Equal(test, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), since)
+	_, err = ParseSince("yesterday", now)
+	require.ErrorIs(test, err, ErrInvalidSince)
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func TestGetBackups(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "audit.log")
+	for _, suffix := range []string{".1", ".2", ".10", ".bak", ".0"} {
+		require.NoError(test, os.WriteFile(filename+suffix, nil, 0o600))
+	}
+	assert.Equal(test, []string{filename + ".10", filename + ".2", filename + ".1"}, getBackups(filename))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getTestObject(test *testing.T) *BasicAudit {
+	audit := &BasicAudit{
+		File: filepath.Join(test.TempDir(), "audit", "audit.log"),
+	}
+	require.NoError(test, audit.Open())
+	test.Cleanup(func() {
+		_ = audit.Close()
+	})
+	return audit
+}
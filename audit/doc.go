@@ -0,0 +1,7 @@
+/*
+Package audit appends a record of each administrative and data-changing operation
+to a log of JSON lines: who called which gRPC method or changed data through the
+Senzing REST API or Connect, and when xterm sessions started and stopped.
+The log is rotated by size, and can be queried with Read.
+*/
+package audit
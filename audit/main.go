@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Filter selects records of the audit log.  Zero fields select every record.
+type Filter struct {
+	Limit     int
+	Operation string
+	Service   string
+	Since     time.Time
+	User      string
+}
+
+// Record is one line of the audit log.
+type Record struct {
+	Error         string    `json:"error,omitempty"`
+	Operation     string    `json:"operation"`
+	RemoteAddress string    `json:"remoteAddress,omitempty"`
+	Role          string    `json:"role,omitempty"`
+	Service       string    `json:"service"`
+	Status        string    `json:"status,omitempty"`
+	Time          time.Time `json:"time"`
+	User          string    `json:"user,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identfier of the  package found messages having the format "senzing-6215xxxx".
+const ComponentID = 6215
+
+// Default number of rotated files kept.
+const DefaultMaxBackups = 5
+
+// Default size, in bytes, at which the audit log is rotated.
+const DefaultMaxSize = 10 * 1024 * 1024
+
+// Operations recorded for xterm sessions.
+const (
+	OperationSessionStart = "session start"
+	OperationSessionStop  = "session stop"
+)
+
+// Services recorded for operations that are not HTTP requests.
+const (
+	ServiceGrpc  = "grpc"
+	ServiceXterm = "xterm"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidRecord is returned by Read for a line of the audit log that is not a JSON record.
+var ErrInvalidRecord = errors.New("audit: invalid record")
+
+// ErrInvalidSince is returned by ParseSince for a value that is neither a time nor a duration.
+var ErrInvalidSince = errors.New("audit: invalid start time")
+
+// ErrMissingFile is returned by Open when File is not set.
+var ErrMissingFile = errors.New("audit: no file to write the audit log to")
+
+// Message templates.
+var IDMessages = map[int]string{
+	4001: "Could not write to audit log %s.",
+	4002: "Could not rotate audit log %s.",
+}
+
+// Status strings for specific messages.
+var IDStatuses = map[int]string{}
@@ -0,0 +1,287 @@
+package authentication
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicAuthentication requires every request and gRPC call to carry credentials
+that one of its Authenticators accepts, and the caller to have the role the request
+or call requires.  The Identity it authenticated is added to the context of the
+request or call; see IdentityFromContext.
+An Identity is given the role Roles maps its name to; otherwise the role its
+Authenticator gave it; otherwise DefaultRole, or RoleViewer if that is not set.
+Every method of a nil *BasicAuthentication is a no-op that returns its input unchanged,
+so authentication can be disabled by passing nil.
+*/
+type BasicAuthentication struct {
+	Authenticators []Authenticator
+	DefaultRole    string
+	Realm          string
+	Roles          map[string]string
+}
+
+// identifiedStream is a gRPC server stream whose context carries an Identity.
+type identifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+type contextKey int
+
+const identityContextKey contextKey = iota
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Authenticate method verifies the value of an "Authorization" header,
+with each Authenticator of its scheme in turn.
+
+Input
+  - ctx: A context to control lifecycle.
+  - authorization: "scheme credentials".  Example: "Bearer 0123456789abcdef".
+
+Output
+  - The Identity of the first Authenticator that accepts the credentials, with its role.
+    For a nil *BasicAuthentication, nil and no error.
+*/
+func (authentication *BasicAuthentication) Authenticate(ctx context.Context, authorization string) (*Identity, error) {
+	if authentication == nil {
+		return nil, nil
+	}
+	scheme, credentials, _ := strings.Cut(strings.TrimSpace(authorization), " ")
+	credentials = strings.TrimSpace(credentials)
+	err := ErrMissingCredentials
+	for _, authenticator := range authentication.Authenticators {
+		if !strings.EqualFold(scheme, authenticator.Scheme()) || len(credentials) == 0 {
+			continue
+		}
+		var identity *Identity
+		identity, err = authenticator.Authenticate(ctx, credentials)
+		if err == nil {
+			identity.Role = authentication.getRole(identity)
+			return identity, nil
+		}
+	}
+	return nil, err
+}
+
+/*
+The GrpcServerOptions method returns options that authenticate each call a gRPC server handles,
+using the "authorization" metadata, and check the caller has the role the call requires.
+Calls are refused as "unauthenticated" without acceptable credentials, and as
+"permission denied" without the role.
+
+Input
+  - getRole: Returns the role a method requires, given its full name.  RoleNone if it needs no credentials.
+
+Output
+  - Options for grpc.NewServer.
+*/
+func (authentication *BasicAuthentication) GrpcServerOptions(getRole func(fullMethod string) string) []grpc.ServerOption {
+	if authentication == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := authentication.authenticateCall(ctx, getRole(info.FullMethod))
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, request)
+		}),
+		grpc.ChainStreamInterceptor(func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := authentication.authenticateCall(stream.Context(), getRole(info.FullMethod))
+			if err != nil {
+				return err
+			}
+			return handler(server, &identifiedStream{ServerStream: stream, ctx: ctx})
+		}),
+	}
+}
+
+/*
+The Handler method authenticates each request before a handler serves it.
+Requests without acceptable credentials are answered "401 Unauthorized", with a
+"WWW-Authenticate" challenge for each scheme, so browsers prompt for a user name and password.
+Requests from callers without the role they require are answered "403 Forbidden".
+The "Authorization" header is removed before the request is served, so it is not proxied.
+
+Input
+  - handler: The handler to protect.
+  - getRole: Returns the role a request requires.  RoleNone if it needs no credentials.  Example: liveness probes.
+
+Output
+  - The protected handler.
+*/
+func (authentication *BasicAuthentication) Handler(handler http.Handler, getRole func(r *http.Request) string) http.Handler {
+	if authentication == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := getRole(r)
+		if role == RoleNone {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		identity, err := authentication.Authenticate(r.Context(), r.Header.Get("Authorization"))
+		if err != nil {
+			for _, scheme := range authentication.getSchemes() {
+				w.Header().Add("WWW-Authenticate", fmt.Sprintf("%s realm=%q", scheme, authentication.Realm))
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		if !identity.HasRole(role) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		r = r.WithContext(ContextWithIdentity(r.Context(), identity))
+		r.Header.Del("Authorization")
+		handler.ServeHTTP(w, r)
+	})
+}
+
+/*
+The HasRole method reports whether an Identity may do what a role may.
+A nil Identity has no role.
+
+Input
+  - role: One of the Role constants.  Anyone has RoleNone.
+*/
+func (identity *Identity) HasRole(role string) bool {
+	if role == RoleNone {
+		return true
+	}
+	if identity == nil {
+		return false
+	}
+	return roleRanks[identity.Role] >= roleRanks[role] && roleRanks[role] > 0
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The ContextWithIdentity function returns a copy of ctx carrying an Identity.
+func ContextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey, identity)
+}
+
+// The IdentityFromContext function returns the Identity a context carries, or nil.
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey).(*Identity)
+	return identity
+}
+
+// The IsRole function reports whether a name is one of the Role constants, other than RoleNone.
+func IsRole(name string) bool {
+	_, isRole := roleRanks[name]
+	return isRole
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Authenticate a gRPC call requiring a role, returning its context with the Identity added.
+func (authentication *BasicAuthentication) authenticateCall(ctx context.Context, role string) (context.Context, error) {
+	if role == RoleNone {
+		return ctx, nil
+	}
+	authorization := ""
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+	identity, err := authentication.Authenticate(ctx, authorization)
+	if err != nil {
+		if !errors.Is(err, ErrMissingCredentials) {
+			err = ErrInvalidCredentials
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !identity.HasRole(role) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s role is required; %s is a %s", role, identity.Name, identity.Role)
+	}
+	return ContextWithIdentity(ctx, identity), nil
+}
+
+// The role of an authenticated Identity.
+func (authentication *BasicAuthentication) getRole(identity *Identity) string {
+	if role, isListed := authentication.Roles[identity.Name]; isListed {
+		return role
+	}
+	if len(identity.Role) > 0 {
+		return identity.Role
+	}
+	if len(authentication.DefaultRole) > 0 {
+		return authentication.DefaultRole
+	}
+	return RoleViewer
+}
+
+// The schemes of the Authenticators, each once, in order.
+func (authentication *BasicAuthentication) getSchemes() []string {
+	result := []string{}
+	for _, authenticator := range authentication.Authenticators {
+		scheme := authenticator.Scheme()
+		isListed := false
+		for _, listed := range result {
+			isListed = isListed || listed == scheme
+		}
+		if !isListed {
+			result = append(result, scheme)
+		}
+	}
+	return result
+}
+
+// --- identifiedStream -------------------------------------------------------
+
+func (stream *identifiedStream) Context() context.Context {
+	return stream.ctx
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Read a file of "name:secret" lines, ignoring blank lines and "#" comments.
+func readSecretsFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	result := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, secret, isPair := strings.Cut(line, ":")
+		if !isPair || len(name) == 0 || len(secret) == 0 {
+			return nil, fmt.Errorf("%w: %s: line %d is not \"name:secret\"", ErrInvalidFile, filename, lineNumber)
+		}
+		result[name] = secret
+	}
+	return result, scanner.Err()
+}
@@ -0,0 +1,394 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestUsersAuthenticator_Authenticate(test *testing.T) {
+	ctx := context.TODO()
+	authenticator, err := NewUsersAuthenticator(writeUsersFile(test))
+	require.NoError(test, err)
+	assert.Equal(test, SchemeBasic, authenticator.Scheme())
+	for range 2 {
+		identity, err := authenticator.Authenticate(ctx, basicCredentials("alice", "secret"))
+		require.NoError(test, err)
+		assert.Equal(test, &Identity{Method: MethodUsers, Name: "alice"}, identity)
+	}
+	_, err = authenticator.Authenticate(ctx, basicCredentials("alice", "wrong"))
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+	_, err = authenticator.Authenticate(ctx, basicCredentials("mallory", "secret"))
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+	_, err = authenticator.Authenticate(ctx, "not base64")
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+}
+
+func TestTokensAuthenticator_Authenticate(test *testing.T) {
+	ctx := context.TODO()
+	authenticator, err := NewTokensAuthenticator(writeFile(test, "# CI\nci:0123456789abcdef\n"))
+	require.NoError(test, err)
+	assert.Equal(test, SchemeBearer, authenticator.Scheme())
+	identity, err := authenticator.Authenticate(ctx, "0123456789abcdef")
+	require.NoError(test, err)
+	assert.Equal(test, &Identity{Method: MethodTokens, Name: "ci"}, identity)
+	_, err = authenticator.Authenticate(ctx, "fedcba9876543210")
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+}
+
+func TestOIDCAuthenticator_Authenticate(test *testing.T) {
+	ctx := context.TODO()
+	issuer := newTestIssuer(test)
+	authenticator := &OIDCAuthenticator{
+		Audience:      "playground",
+		Issuer:        issuer.URL,
+		UsernameClaim: "email",
+	}
+	assert.Equal(test, SchemeBearer, authenticator.Scheme())
+	identity, err := authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"aud": "playground", "email": "bob@example.com"}))
+	require.NoError(test, err)
+	assert.Equal(test, &Identity{Method: MethodOIDC, Name: "bob@example.com"}, identity)
+
+	_, err = authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"aud": "other", "email": "bob@example.com"}))
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+	_, err = authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"aud": "playground", "exp": time.Now().Add(-time.Minute).Unix()}))
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+	_, err = authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"aud": "playground"}))
+	require.ErrorIs(test, err, ErrInvalidCredentials, "no email claim")
+}
+
+func TestOIDCAuthenticator_Authenticate_roleClaim(test *testing.T) {
+	ctx := context.TODO()
+	issuer := newTestIssuer(test)
+	authenticator := &OIDCAuthenticator{
+		Issuer:    issuer.URL,
+		RoleClaim: "groups",
+	}
+	identity, err := authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"groups": []string{"staff", RoleLoader, RoleViewer}}))
+	require.NoError(test, err)
+	assert.Equal(test, &Identity{Method: MethodOIDC, Name: "1234", Role: RoleLoader}, identity)
+	identity, err = authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"groups": RoleAdmin}))
+	require.NoError(test, err)
+	assert.Equal(test, RoleAdmin, identity.Role)
+	identity, err = authenticator.Authenticate(ctx, issuer.token(test, map[string]any{"groups": []string{"staff"}}))
+	require.NoError(test, err)
+	assert.Equal(test, RoleNone, identity.Role)
+}
+
+func TestOIDCAuthenticator_Authenticate_unreachable(test *testing.T) {
+	ctx := context.TODO()
+	issuer := newTestIssuer(test)
+	issuer.Close()
+	authenticator := &OIDCAuthenticator{Issuer: issuer.URL}
+	_, err := authenticator.Authenticate(ctx, "token")
+	require.Error(test, err)
+	assert.Nil(test, authenticator.verifier, "discovery is retried")
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestBasicAuthentication_Authenticate(test *testing.T) {
+	ctx := context.TODO()
+	authentication := getTestObject(test)
+	identity, err := authentication.Authenticate(ctx, "Basic "+basicCredentials("alice", "secret"))
+	require.NoError(test, err)
+	assert.Equal(test, &Identity{Method: MethodUsers, Name: "alice", Role: RoleAdmin}, identity)
+	identity, err = authentication.Authenticate(ctx, "bearer 0123456789abcdef")
+	require.NoError(test, err)
+	assert.Equal(test, &Identity{Method: MethodTokens, Name: "ci", Role: RoleViewer}, identity)
+	authentication.DefaultRole = RoleLoader
+	identity, err = authentication.Authenticate(ctx, "Bearer 0123456789abcdef")
+	require.NoError(test, err)
+	assert.Equal(test, RoleLoader, identity.Role)
+	_, err = authentication.Authenticate(ctx, "Bearer fedcba9876543210")
+	require.ErrorIs(test, err, ErrInvalidCredentials)
+	_, err = authentication.Authenticate(ctx, "")
+	require.ErrorIs(test, err, ErrMissingCredentials)
+	_, err = authentication.Authenticate(ctx, "Digest username=alice")
+	require.ErrorIs(test, err, ErrMissingCredentials)
+
+	var nilAuthentication *BasicAuthentication
+	identity, err = nilAuthentication.Authenticate(ctx, "")
+	require.NoError(test, err)
+	assert.Nil(test, identity)
+}
+
+func TestBasicAuthentication_Handler(test *testing.T) {
+	authentication := getTestObject(test)
+	handler := authentication.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(test, r.Header.Get("Authorization"), "credentials are not passed on")
+		identity := IdentityFromContext(r.Context())
+		if identity != nil {
+			_, _ = w.Write([]byte(identity.Name))
+		}
+	}), func(r *http.Request) string {
+		switch r.URL.Path {
+		case "/healthz":
+			return RoleNone
+		case "/xterm":
+			return RoleAdmin
+		default:
+			return RoleViewer
+		}
+	})
+
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/site/home.html", nil))
+	assert.Equal(test, http.StatusUnauthorized, response.Code)
+	assert.Equal(test, []string{`Basic realm="playground"`, `Bearer realm="playground"`}, response.Header().Values("WWW-Authenticate"))
+
+	request := httptest.NewRequest(http.MethodGet, "/site/home.html", nil)
+	request.SetBasicAuth("alice", "secret")
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Equal(test, "alice", response.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, "/xterm", nil)
+	request.Header.Set("Authorization", "Bearer 0123456789abcdef")
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(test, http.StatusForbidden, response.Code, "ci is a viewer")
+}
+
+func TestBasicAuthentication_Handler_nil(test *testing.T) {
+	var authentication *BasicAuthentication
+	response := httptest.NewRecorder()
+	authentication.Handler(http.NotFoundHandler(), nil).ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(test, http.StatusNotFound, response.Code)
+	assert.Nil(test, authentication.GrpcServerOptions(nil))
+}
+
+func TestBasicAuthentication_GrpcServerOptions(test *testing.T) {
+	ctx := context.TODO()
+	authentication := getTestObject(test)
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(authentication.GrpcServerOptions(func(fullMethod string) string {
+		switch fullMethod {
+		case "/grpc.health.v1.Health/Check":
+			return RoleNone
+		case "/test.Identity/Watch":
+			return RoleAdmin
+		default:
+			return RoleViewer
+		}
+	})...)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	server.RegisterService(&identityServiceDesc, struct{}{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer connection.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(connection).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err, "health checks need no credentials")
+
+	reply := &wrapperspb.StringValue{}
+	err = connection.Invoke(ctx, "/test.Identity/Get", &emptypb.Empty{}, reply)
+	assert.Equal(test, codes.Unauthenticated, status.Code(err))
+	err = connection.Invoke(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer fedcba9876543210"), "/test.Identity/Get", &emptypb.Empty{}, reply)
+	assert.Equal(test, codes.Unauthenticated, status.Code(err))
+	err = connection.Invoke(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer 0123456789abcdef"), "/test.Identity/Get", &emptypb.Empty{}, reply)
+	require.NoError(test, err)
+	assert.Equal(test, "ci", reply.GetValue())
+
+	stream, err := connection.NewStream(metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+basicCredentials("alice", "secret")), &identityServiceDesc.Streams[0], "/test.Identity/Watch")
+	require.NoError(test, err)
+	require.NoError(test, stream.RecvMsg(reply))
+	assert.Equal(test, "alice", reply.GetValue())
+	stream, err = connection.NewStream(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer 0123456789abcdef"), &identityServiceDesc.Streams[0], "/test.Identity/Watch")
+	require.NoError(test, err)
+	assert.Equal(test, codes.PermissionDenied, status.Code(stream.RecvMsg(reply)), "ci is a viewer")
+	stream, err = connection.NewStream(ctx, &identityServiceDesc.Streams[0], "/test.Identity/Watch")
+	require.NoError(test, err)
+	assert.Equal(test, codes.Unauthenticated, status.Code(stream.RecvMsg(reply)))
+}
+
+func TestIdentity_HasRole(test *testing.T) {
+	var nobody *Identity
+	assert.True(test, nobody.HasRole(RoleNone))
+	assert.False(test, nobody.HasRole(RoleViewer))
+	loader := &Identity{Name: "loader", Role: RoleLoader}
+	assert.True(test, loader.HasRole(RoleViewer))
+	assert.True(test, loader.HasRole(RoleLoader))
+	assert.False(test, loader.HasRole(RoleAdmin))
+	assert.False(test, loader.HasRole("superuser"))
+	assert.False(test, (&Identity{Name: "unknown", Role: "superuser"}).HasRole(RoleViewer))
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestIsRole(test *testing.T) {
+	assert.True(test, IsRole(RoleViewer))
+	assert.True(test, IsRole(RoleLoader))
+	assert.True(test, IsRole(RoleAdmin))
+	assert.False(test, IsRole(RoleNone))
+	assert.False(test, IsRole("superuser"))
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func TestReadSecretsFile(test *testing.T) {
+	secrets, err := readSecretsFile(writeFile(test, "# Users\n\nalice:a:b\n  bob:c  \n"))
+	require.NoError(test, err)
+	assert.Equal(test, map[string]string{"alice": "a:b", "bob": "c"}, secrets)
+	_, err = readSecretsFile(writeFile(test, "alice:a\nbob\n"))
+	require.ErrorIs(test, err, ErrInvalidFile)
+	require.ErrorContains(test, err, "line 2")
+	_, err = readSecretsFile(filepath.Join(test.TempDir(), "missing"))
+	require.ErrorIs(test, err, os.ErrNotExist)
+	_, err = NewUsersAuthenticator(writeFile(test, "alice:not-a-bcrypt-hash\n"))
+	require.ErrorIs(test, err, ErrInvalidFile)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// identityServiceDesc is a gRPC service answering with the name of the caller's Identity.
+var identityServiceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Identity",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Get",
+		Handler: func(_ any, ctx context.Context, decode func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			request := &emptypb.Empty{}
+			if err := decode(request); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, _ any) (any, error) {
+				return wrapperspb.String(IdentityFromContext(ctx).Name), nil
+			}
+			return interceptor(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/test.Identity/Get"}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName: "Watch",
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			return stream.SendMsg(wrapperspb.String(IdentityFromContext(stream.Context()).Name))
+		},
+		ServerStreams: true,
+	}},
+}
+
+// testIssuer is a stand-in OpenID Connect provider.
+type testIssuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newTestIssuer(test *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(test, err)
+	issuer := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+			"issuer":                                issuer.URL,
+			"jwks_uri":                              issuer.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Algorithm: string(jose.RS256), Key: &key.PublicKey, KeyID: "test", Use: "sig"},
+		}})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	test.Cleanup(issuer.Close)
+	return issuer
+}
+
+// Sign a token with the issuer's key.  Claims override the default ones.
+func (issuer *testIssuer) token(test *testing.T, claims map[string]any) string {
+	payload := map[string]any{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+		"iss": issuer.URL,
+		"sub": "1234",
+	}
+	for key, value := range claims {
+		payload[key] = value
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: issuer.key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	require.NoError(test, err)
+	payloadJSON, err := json.Marshal(payload)
+	require.NoError(test, err)
+	signed, err := signer.Sign(payloadJSON)
+	require.NoError(test, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(test, err)
+	return token
+}
+
+func basicCredentials(name string, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(name + ":" + password))
+}
+
+func getTestObject(test *testing.T) *BasicAuthentication {
+	users, err := NewUsersAuthenticator(writeUsersFile(test))
+	require.NoError(test, err)
+	tokens, err := NewTokensAuthenticator(writeFile(test, "ci:0123456789abcdef\n"))
+	require.NoError(test, err)
+	return &BasicAuthentication{
+		Authenticators: []Authenticator{users, tokens},
+		Realm:          "playground",
+		Roles:          map[string]string{"alice": RoleAdmin},
+	}
+}
+
+func writeFile(test *testing.T, contents string) string {
+	filename := filepath.Join(test.TempDir(), "secrets")
+	require.NoError(test, os.WriteFile(filename, []byte(contents), 0o600))
+	return filename
+}
+
+func writeUsersFile(test *testing.T) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(test, err)
+	return writeFile(test, "alice:"+string(hash)+"\n")
+}
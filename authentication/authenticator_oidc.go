@@ -0,0 +1,123 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+OIDCAuthenticator accepts bearer tokens signed by an OpenID Connect provider.
+The provider is discovered from Issuer on first use, and again after a failure,
+so the playground can start before the provider does.
+With Audience, tokens must be issued for it.
+The user name is the UsernameClaim of the token, DefaultUsernameClaim if not set.
+With RoleClaim, the user is given the most privileged role that claim names,
+either as a string or as a list of strings such as groups.
+*/
+type OIDCAuthenticator struct {
+	Audience      string
+	Issuer        string
+	mutex         sync.Mutex
+	RoleClaim     string
+	UsernameClaim string
+	verifier      *oidc.IDTokenVerifier
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Authenticate method verifies the signature, issuer, audience, and expiry of a token.
+
+Input
+  - ctx: A context to control lifecycle.
+  - credentials: The token, a JSON Web Token.
+
+Output
+  - The user named by the token.
+*/
+func (authenticator *OIDCAuthenticator) Authenticate(ctx context.Context, credentials string) (*Identity, error) {
+	verifier, err := authenticator.getVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := verifier.Verify(ctx, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	claims := map[string]any{}
+	err = token.Claims(&claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	name, isString := claims[authenticator.getUsernameClaim()].(string)
+	if !isString || len(name) == 0 {
+		return nil, fmt.Errorf("%w: no %q claim", ErrInvalidCredentials, authenticator.getUsernameClaim())
+	}
+	return &Identity{Method: MethodOIDC, Name: name, Role: authenticator.getRole(claims)}, nil
+}
+
+// The Scheme method returns SchemeBearer.
+func (authenticator *OIDCAuthenticator) Scheme() string {
+	return SchemeBearer
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Discover the provider, unless already done.  Requests to it, including for its keys, time out after oidcTimeout.
+func (authenticator *OIDCAuthenticator) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	authenticator.mutex.Lock()
+	defer authenticator.mutex.Unlock()
+	if authenticator.verifier != nil {
+		return authenticator.verifier, nil
+	}
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, &http.Client{Timeout: oidcTimeout}), authenticator.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	authenticator.verifier = provider.Verifier(&oidc.Config{
+		ClientID:          authenticator.Audience,
+		SkipClientIDCheck: len(authenticator.Audience) == 0,
+	})
+	return authenticator.verifier, nil
+}
+
+// The most privileged role named by the RoleClaim, or "" for none.
+func (authenticator *OIDCAuthenticator) getRole(claims map[string]any) string {
+	if len(authenticator.RoleClaim) == 0 {
+		return RoleNone
+	}
+	var names []any
+	switch value := claims[authenticator.RoleClaim].(type) {
+	case string:
+		names = []any{value}
+	case []any:
+		names = value
+	}
+	result := RoleNone
+	for _, name := range names {
+		role, isString := name.(string)
+		if isString && roleRanks[role] > roleRanks[result] {
+			result = role
+		}
+	}
+	return result
+}
+
+func (authenticator *OIDCAuthenticator) getUsernameClaim() string {
+	if len(authenticator.UsernameClaim) == 0 {
+		return DefaultUsernameClaim
+	}
+	return authenticator.UsernameClaim
+}
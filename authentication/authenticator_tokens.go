@@ -0,0 +1,75 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+TokensAuthenticator accepts bearer tokens listed in a file.
+Each line of the file is "name:token".  Blank lines and lines starting with "#" are ignored.
+Keep the file readable only by the playground.
+*/
+type TokensAuthenticator struct {
+	names map[[sha256.Size]byte]string
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewTokensAuthenticator function reads a tokens file.
+
+Input
+  - filename: Path of the tokens file.
+
+Output
+  - An authenticator of the tokens in the file.
+*/
+func NewTokensAuthenticator(filename string) (*TokensAuthenticator, error) {
+	secrets, err := readSecretsFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	result := &TokensAuthenticator{
+		names: map[[sha256.Size]byte]string{},
+	}
+	for name, token := range secrets {
+		result.names[sha256.Sum256([]byte(token))] = name
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Authenticate method looks up a token.
+Tokens are compared by their SHA-256 hash, so the time taken does not reveal them.
+
+Input
+  - ctx: A context to control lifecycle.
+  - credentials: The token.
+
+Output
+  - The name the token belongs to.
+*/
+func (authenticator *TokensAuthenticator) Authenticate(ctx context.Context, credentials string) (*Identity, error) {
+	_ = ctx
+	name, isToken := authenticator.names[sha256.Sum256([]byte(credentials))]
+	if !isToken {
+		return nil, ErrInvalidCredentials
+	}
+	return &Identity{Method: MethodTokens, Name: name}, nil
+}
+
+// The Scheme method returns SchemeBearer.
+func (authenticator *TokensAuthenticator) Scheme() string {
+	return SchemeBearer
+}
@@ -0,0 +1,107 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+UsersAuthenticator accepts HTTP Basic credentials of the users in a file.
+Each line of the file is "name:hash", where hash is a bcrypt hash of the password,
+as written by "htpasswd -B".  Blank lines and lines starting with "#" are ignored.
+*/
+type UsersAuthenticator struct {
+	hashes   map[string][]byte
+	mutex    sync.Mutex
+	verified map[[sha256.Size]byte]string
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewUsersAuthenticator function reads a users file.
+
+Input
+  - filename: Path of the users file.
+
+Output
+  - An authenticator of the users in the file.
+*/
+func NewUsersAuthenticator(filename string) (*UsersAuthenticator, error) {
+	secrets, err := readSecretsFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	result := &UsersAuthenticator{
+		hashes:   map[string][]byte{},
+		verified: map[[sha256.Size]byte]string{},
+	}
+	for name, hash := range secrets {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("%w: %s: user %s: %w", ErrInvalidFile, filename, name, err)
+		}
+		result.hashes[name] = []byte(hash)
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Authenticate method verifies a user name and password.
+Verified credentials are remembered, so a browser sending them with every request
+does not pay for a bcrypt comparison each time.
+
+Input
+  - ctx: A context to control lifecycle.
+  - credentials: base64 of "name:password".
+
+Output
+  - The user.
+*/
+func (authenticator *UsersAuthenticator) Authenticate(ctx context.Context, credentials string) (*Identity, error) {
+	_ = ctx
+	key := sha256.Sum256([]byte(credentials))
+	authenticator.mutex.Lock()
+	name, isVerified := authenticator.verified[key]
+	authenticator.mutex.Unlock()
+	if isVerified {
+		return &Identity{Method: MethodUsers, Name: name}, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(credentials)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	name, password, isPair := strings.Cut(string(decoded), ":")
+	hash, isUser := authenticator.hashes[name]
+	if !isPair || !isUser {
+		return nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	authenticator.mutex.Lock()
+	authenticator.verified[key] = name
+	authenticator.mutex.Unlock()
+	return &Identity{Method: MethodUsers, Name: name}, nil
+}
+
+// The Scheme method returns SchemeBasic.
+func (authenticator *UsersAuthenticator) Scheme() string {
+	return SchemeBasic
+}
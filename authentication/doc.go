@@ -0,0 +1,6 @@
+/*
+Package authentication requires clients of the playground's HTTP routes and gRPC
+services to identify themselves: with a user name and password from a users file,
+with a token from a tokens file, or with a token issued by an OpenID Connect provider.
+*/
+package authentication
@@ -0,0 +1,82 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+An Authenticator verifies the credentials of one authorization scheme.
+The credentials are what follows the scheme in an "Authorization" header.
+*/
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials string) (*Identity, error)
+	Scheme() string
+}
+
+// Identity is who a client was authenticated as, how, and the role they were given.
+type Identity struct {
+	Method string `json:"method"`
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Methods of authentication, as reported in Identity.Method.
+const (
+	MethodOIDC   = "oidc"
+	MethodTokens = "tokens"
+	MethodUsers  = "users"
+)
+
+// Authorization schemes.
+const (
+	SchemeBasic  = "Basic"
+	SchemeBearer = "Bearer"
+)
+
+// Roles, from least to most privileged.  Each role may do what the roles before it may.
+// RoleNone is required of requests and calls that need no credentials.
+const (
+	RoleNone   = ""
+	RoleViewer = "viewer"
+	RoleLoader = "loader"
+	RoleAdmin  = "admin"
+)
+
+// Claim of an OpenID Connect token used as the user name, when none is configured.
+const DefaultUsernameClaim = "sub"
+
+// How long a request to an OpenID Connect provider may take.
+const oidcTimeout = 10 * time.Second
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrInvalidCredentials is returned by an Authenticator for credentials it does not accept.
+var ErrInvalidCredentials = errors.New("authentication: invalid credentials")
+
+// ErrInvalidFile is returned when a line of a users or tokens file is not "name:secret".
+var ErrInvalidFile = errors.New("authentication: invalid file")
+
+// ErrMissingCredentials is returned when a request has no credentials of a configured scheme.
+var ErrMissingCredentials = errors.New("authentication: missing credentials")
+
+// ErrUnknownRole is returned for a role that is not one of the Role constants.
+var ErrUnknownRole = errors.New("authentication: unknown role")
+
+// Rank of each role.  A higher rank may do what a lower one may.
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleLoader: 2,
+	RoleAdmin:  3,
+}
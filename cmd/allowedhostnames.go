@@ -0,0 +1,53 @@
+/*
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var allowedHostnamesContextVariables = []option.ContextVariable{
+	option.Configuration,
+	xtermAllowedHostnames,
+}
+
+// allowedHostnamesCmd represents the allowed-hostnames command
+var allowedHostnamesCmd = &cobra.Command{
+	Use:   "allowed-hostnames",
+	Short: "List the hostnames permitted to connect to the xterm websocket",
+	Long: `List the hostnames permitted to connect to the xterm websocket, one per line.
+
+By default, the list is "localhost", this machine's hostname, and the IP addresses
+of its network interfaces.  To override it, set --xterm-allowed-hostnames or
+SENZING_TOOLS_XTERM_ALLOWED_HOSTNAMES; this command then lists the override.
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, allowedHostnamesContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = cmd
+		_ = args
+		return allowedHostnamesAction(os.Stdout, viper.GetStringSlice(xtermAllowedHostnames.Arg))
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(allowedHostnamesCmd)
+	cmdhelper.Init(allowedHostnamesCmd, allowedHostnamesContextVariables)
+}
+
+func allowedHostnamesAction(out io.Writer, allowedHostnames []string) error {
+	for _, allowedHostname := range allowedHostnames {
+		if _, err := fmt.Fprintln(out, allowedHostname); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+ */
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/playground/audit"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrMissingAuditFile is returned by the audit command when no audit log is configured.
+var ErrMissingAuditFile = errors.New("no audit log: set --audit-file or SENZING_TOOLS_AUDIT_FILE")
+
+var auditContextVariables = []option.ContextVariable{
+	auditFile,
+	option.Configuration,
+}
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List records of the audit log",
+	Long: `List who changed data or configuration over gRPC, the Senzing REST API, or Connect,
+and who opened xterm sessions, oldest first.  Rotated files of the audit log are included.
+
+Use the same --audit-file as the running playground.
+
+Examples:
+    playground audit --since 24h
+    playground audit --user alice --operation PurgeRepository --json
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, auditContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		cmd.SilenceUsage = true
+		filter, err := getAuditFilter(cmd)
+		if err != nil {
+			return err
+		}
+		asJSON, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			return err
+		}
+		return auditAction(os.Stdout, viper.GetString(auditFile.Arg), filter, asJSON)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+	cmdhelper.Init(auditCmd, auditContextVariables)
+	auditCmd.Flags().Bool("json", false, "List records as JSON lines, as written to the audit log")
+	auditCmd.Flags().Int("limit", 0, "List only the most recent records. 0 for all")
+	auditCmd.Flags().String("operation", "", "List only operations containing this text, ignoring case. Example: PurgeRepository")
+	auditCmd.Flags().String("service", "", "List only operations of this service. Example: grpc, senzing-rest-api, connect, or xterm")
+	auditCmd.Flags().String("since", "", "List only records since a time, or for a duration. Example: 2024-01-01T00:00:00Z or 24h")
+	auditCmd.Flags().String("user", "", "List only operations by this user")
+}
+
+func auditAction(out io.Writer, filename string, filter audit.Filter, asJSON bool) error {
+	if len(filename) == 0 {
+		return ErrMissingAuditFile
+	}
+	records, err := audit.Read(filename, filter)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		for _, record := range records {
+			err = encoder.Encode(record)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, err = fmt.Fprintln(writer, "TIME\tUSER\tROLE\tSERVICE\tOPERATION\tSTATUS\tREMOTE ADDRESS")
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		_, err = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			record.Time.Local().Format(time.RFC3339),
+			orDash(record.User),
+			orDash(record.Role),
+			record.Service,
+			record.Operation,
+			orDash(record.Status),
+			orDash(record.RemoteAddress),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// The filter the flags of the audit command describe.
+func getAuditFilter(cmd *cobra.Command) (audit.Filter, error) {
+	result := audit.Filter{}
+	var err error
+	result.Limit, err = cmd.Flags().GetInt("limit")
+	if err != nil {
+		return result, err
+	}
+	result.Operation, err = cmd.Flags().GetString("operation")
+	if err != nil {
+		return result, err
+	}
+	result.Service, err = cmd.Flags().GetString("service")
+	if err != nil {
+		return result, err
+	}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return result, err
+	}
+	result.Since, err = audit.ParseSince(since, time.Now())
+	if err != nil {
+		return result, err
+	}
+	result.User, err = cmd.Flags().GetString("user")
+	return result, err
+}
+
+// A value, or "-" if it is empty, so columns stay aligned.
+func orDash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
@@ -2,9 +2,23 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/senzing-garage/playground/audit"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +32,12 @@ func Test_Execute(test *testing.T) {
 	Execute()
 }
 
+func Test_Execute_allowedHostnames(test *testing.T) {
+	_ = test
+	os.Args = []string{"command-name", "allowed-hostnames", "--xterm-allowed-hostnames", "example.com"}
+	Execute()
+}
+
 func Test_Execute_completion(test *testing.T) {
 	_ = test
 	os.Args = []string{"command-name", "completion"}
@@ -63,6 +83,14 @@ func Test_RootCmd(test *testing.T) {
 	require.NoError(test, err)
 }
 
+func Test_allowedHostnamesCmd(test *testing.T) {
+	_ = test
+	err := allowedHostnamesCmd.Execute()
+	require.NoError(test, err)
+	err = allowedHostnamesCmd.RunE(allowedHostnamesCmd, []string{})
+	require.NoError(test, err)
+}
+
 func Test_completionCmd(test *testing.T) {
 	_ = test
 	err := completionCmd.Execute()
@@ -83,15 +111,579 @@ func Test_docsCmd(test *testing.T) {
 // Test private functions
 // ----------------------------------------------------------------------------
 
+func Test_auditAction(test *testing.T) {
+	playgroundAudit := &audit.BasicAudit{File: filepath.Join(test.TempDir(), "audit.log")}
+	require.NoError(test, playgroundAudit.Open())
+	playgroundAudit.Record(audit.Record{Operation: "/szengine.SzEngine/AddRecord", Service: audit.ServiceGrpc, Status: "OK", User: "alice"})
+	playgroundAudit.Record(audit.Record{Operation: "/szdiagnostic.SzDiagnostic/PurgeRepository", Service: audit.ServiceGrpc, Status: "OK"})
+	require.NoError(test, playgroundAudit.Close())
+
+	var buffer bytes.Buffer
+	err := auditAction(&buffer, playgroundAudit.File, audit.Filter{}, false)
+	require.NoError(test, err)
+	require.Contains(test, buffer.String(), "OPERATION")
+	require.Contains(test, buffer.String(), "alice")
+	require.Contains(test, buffer.String(), "PurgeRepository")
+
+	buffer.Reset()
+	err = auditAction(&buffer, playgroundAudit.File, audit.Filter{User: "alice"}, true)
+	require.NoError(test, err)
+	require.Equal(test, 1, strings.Count(buffer.String(), "\n"))
+	require.Contains(test, buffer.String(), `"operation":"/szengine.SzEngine/AddRecord"`)
+
+	err = auditAction(&buffer, "", audit.Filter{}, false)
+	require.ErrorIs(test, err, ErrMissingAuditFile)
+	err = auditAction(&buffer, filepath.Join(test.TempDir(), "missing.log"), audit.Filter{}, false)
+	require.ErrorIs(test, err, fs.ErrNotExist)
+}
+
+func Test_getAuditFilter(test *testing.T) {
+	require.NoError(test, auditCmd.Flags().Set("since", "1h"))
+	require.NoError(test, auditCmd.Flags().Set("user", "alice"))
+	defer func() {
+		require.NoError(test, auditCmd.Flags().Set("since", ""))
+		require.NoError(test, auditCmd.Flags().Set("user", ""))
+	}()
+	filter, err := getAuditFilter(auditCmd)
+	require.NoError(test, err)
+	require.Equal(test, "alice", filter.User)
+	require.WithinDuration(test, time.Now().Add(-time.Hour), filter.Since, time.Minute)
+	require.NoError(test, auditCmd.Flags().Set("since", "yesterday"))
+	_, err = getAuditFilter(auditCmd)
+	require.ErrorIs(test, err, audit.ErrInvalidSince)
+}
+
+func Test_loadAction(test *testing.T) {
+	var buffer bytes.Buffer
+	err := loadAction(context.TODO(), &buffer, &loader.BasicLoader{})
+	require.ErrorIs(test, err, loader.ErrMissingFile)
+	err = loadAction(context.TODO(), &buffer, &loader.BasicLoader{InputFile: filepath.Join(test.TempDir(), "missing.jsonl")})
+	require.ErrorIs(test, err, fs.ErrNotExist)
+}
+
+func Test_mapAction(test *testing.T) {
+	dir := test.TempDir()
+	options := mapOptions{
+		comma:       ',',
+		filename:    filepath.Join(dir, "customers.csv"),
+		mappingFile: filepath.Join(dir, "customers.csv.mapping.json"),
+		outputFile:  filepath.Join(dir, "customers.jsonl"),
+	}
+	require.NoError(test, os.WriteFile(options.filename, []byte("ID,First Name,Surname,Notes\n1001,Robert,Smith,VIP\n"), 0o600))
+
+	var buffer bytes.Buffer
+	require.NoError(test, mapAction(&buffer, options))
+	require.Contains(test, buffer.String(), "Saved a mapping suggested")
+	require.Contains(test, buffer.String(), `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","NAME_FIRST":"Robert","NAME_LAST":"Smith","NOTES":"VIP"}`)
+	require.FileExists(test, options.mappingFile)
+	require.NoFileExists(test, options.outputFile, "a suggested mapping is only shown")
+
+	mapping, err := loader.ReadMapping(options.mappingFile)
+	require.NoError(test, err)
+	mapping.Columns[3].Attribute = ""
+	require.NoError(test, loader.WriteMapping(options.mappingFile, mapping))
+	buffer.Reset()
+	options.dataSource = "CRM"
+	require.NoError(test, mapAction(&buffer, options))
+	require.Contains(test, buffer.String(), "Wrote them to")
+	output, err := os.ReadFile(options.outputFile)
+	require.NoError(test, err)
+	require.Equal(test, `{"DATA_SOURCE":"CRM","RECORD_ID":"1001","NAME_FIRST":"Robert","NAME_LAST":"Smith"}`+"\n", string(output))
+
+	options.comma = 0
+	require.ErrorIs(test, mapAction(&buffer, options), ErrUnknownDelimiter)
+}
+
+func Test_getMapOptions(test *testing.T) {
+	options, err := getMapOptions(mapCmd, "customers.tsv")
+	require.NoError(test, err)
+	require.Equal(test, '\t', options.comma)
+	require.Equal(test, "customers.tsv.mapping.json", options.mappingFile)
+	require.Equal(test, "customers.jsonl", options.outputFile)
+	require.NoError(test, mapCmd.Flags().Set("delimiter", "|"))
+	defer func() {
+		require.NoError(test, mapCmd.Flags().Set("delimiter", ""))
+	}()
+	options, err = getMapOptions(mapCmd, "customers.txt")
+	require.NoError(test, err)
+	require.Equal(test, '|', options.comma)
+	require.NoError(test, mapCmd.Flags().Set("delimiter", "||"))
+	_, err = getMapOptions(mapCmd, "customers.txt")
+	require.ErrorIs(test, err, ErrUnknownDelimiter)
+}
+
+func Test_getLoader(test *testing.T) {
+	recordLoader, err := getLoader(loadCmd, "customers.jsonl")
+	require.NoError(test, err)
+	require.Equal(test, "customers.jsonl.checkpoint", recordLoader.CheckpointFile)
+	require.Equal(test, "customers.jsonl.failures", recordLoader.FailuresFile)
+	require.Equal(test, loader.DefaultThreads, recordLoader.Threads)
+	require.NoError(test, loadCmd.Flags().Set("failures-file", "failed.jsonl"))
+	require.NoError(test, loadCmd.Flags().Set("threads", "8"))
+	defer func() {
+		require.NoError(test, loadCmd.Flags().Set("failures-file", ""))
+		require.NoError(test, loadCmd.Flags().Set("threads", fmt.Sprint(loader.DefaultThreads)))
+	}()
+	recordLoader, err = getLoader(loadCmd, "customers.jsonl")
+	require.NoError(test, err)
+	require.Equal(test, "failed.jsonl", recordLoader.FailuresFile)
+	require.Equal(test, 8, recordLoader.Threads)
+}
+
+func Test_getGrpcDialOptions(test *testing.T) {
+	target, dialOptions, err := getGrpcDialOptions("grpc://localhost:8261", "")
+	require.NoError(test, err)
+	require.Equal(test, "localhost:8261", target)
+	require.Len(test, dialOptions, 1)
+	target, dialOptions, err = getGrpcDialOptions("grpcs://playground.example.com:443", "loadtoken")
+	require.NoError(test, err)
+	require.Equal(test, "playground.example.com:443", target)
+	require.Len(test, dialOptions, 2)
+	for _, grpcURL := range []string{"localhost:8261", "http://localhost:8261", "grpc://", "grpc://bad host:1"} {
+		_, _, err = getGrpcDialOptions(grpcURL, "")
+		require.ErrorIs(test, err, ErrInvalidGrpcURL, grpcURL)
+	}
+}
+
+func Test_getClientTLSConfig(test *testing.T) {
+	var buffer bytes.Buffer
+	dir := test.TempDir()
+	require.NoError(test, generateCertificatesAction(&buffer, dir, []string{"localhost"}))
+	tlsConfig, err := getClientTLSConfig()
+	require.NoError(test, err)
+	require.Nil(test, tlsConfig.RootCAs, "system CAs")
+	require.Empty(test, tlsConfig.Certificates)
+	viper.Set(tlsCAFile.Arg, filepath.Join(dir, "ca.pem"))
+	viper.Set(tlsCertFile.Arg, filepath.Join(dir, "client.pem"))
+	viper.Set(tlsKeyFile.Arg, filepath.Join(dir, "client-key.pem"))
+	defer func() {
+		viper.Set(tlsCAFile.Arg, "")
+		viper.Set(tlsCertFile.Arg, "")
+		viper.Set(tlsKeyFile.Arg, "")
+	}()
+	tlsConfig, err = getClientTLSConfig()
+	require.NoError(test, err)
+	require.NotNil(test, tlsConfig.RootCAs)
+	require.Len(test, tlsConfig.Certificates, 1)
+	viper.Set(tlsCAFile.Arg, filepath.Join(dir, "client-key.pem"))
+	_, err = getClientTLSConfig()
+	require.ErrorIs(test, err, ErrNoCACertificates)
+}
+
+func Test_allowedHostnamesAction(test *testing.T) {
+	var buffer bytes.Buffer
+	err := allowedHostnamesAction(&buffer, []string{"localhost", "example.com"})
+	require.NoError(test, err)
+	require.Equal(test, "localhost\nexample.com\n", buffer.String())
+}
+
 func Test_completionAction(test *testing.T) {
 	var buffer bytes.Buffer
 	err := completionAction(&buffer)
 	require.NoError(test, err)
 }
 
+func Test_healthcheckAction(test *testing.T) {
+	ctx := context.TODO()
+	ready := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"ready": %t, "status": "up", "services": [{"name": "grpc", "state": "up", "latencyMilliseconds": 1.25}]}`, ready)
+	}))
+	defer server.Close()
+	var buffer bytes.Buffer
+	err := healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/status", "")
+	require.NoError(test, err)
+	require.Contains(test, buffer.String(), "Status: up\n")
+	require.Contains(test, buffer.String(), "grpc")
+	require.Contains(test, buffer.String(), "1.2ms")
+	ready = false
+	err = healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/status", "")
+	require.ErrorIs(test, err, ErrNotReady)
+	err = healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/missing", "")
+	require.Error(test, err)
+}
+
+func Test_healthcheckAction_authentication(test *testing.T) {
+	ctx := context.TODO()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/readyz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"ready": false, "status": "starting"}`)
+		case r.Header.Get("Authorization") != "Bearer secret":
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		default:
+			fmt.Fprint(w, `{"ready": true, "status": "up", "services": [{"name": "grpc", "state": "up"}]}`)
+		}
+	}))
+	defer server.Close()
+	var buffer bytes.Buffer
+	require.NoError(test, healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/status", "secret"))
+	require.Contains(test, buffer.String(), "grpc")
+	buffer.Reset()
+	err := healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/status", "")
+	require.ErrorIs(test, err, ErrNotReady, "readiness only, from /readyz")
+	require.Equal(test, "Status: starting\n", buffer.String())
+	err = healthcheckAction(ctx, &buffer, server.Client(), server.URL+"/status", "wrong")
+	require.ErrorContains(test, err, "401")
+}
+
+func Test_getHealthcheckURL(test *testing.T) {
+	viper.Set(basePath.Arg, "/playground")
+	viper.Set(httpTLSSelfSigned.Arg, true)
+	defer func() {
+		viper.Set(basePath.Arg, "")
+		viper.Set(httpTLSSelfSigned.Arg, false)
+	}()
+	require.Regexp(test, `^https://localhost:\d+/playground/status$`, getHealthcheckURL())
+	viper.Set(playgroundHost.Arg, "playground.example.com")
+	defer viper.Set(playgroundHost.Arg, "")
+	require.Regexp(test, `^https://playground.example.com:\d+/playground/status$`, getHealthcheckURL())
+}
+
+func Test_getPlaygroundClient(test *testing.T) {
+	ctx := context.TODO()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		_ = request
+		_, _ = response.Write([]byte(`{"ready": true, "status": "ready"}`))
+	}))
+	defer server.Close()
+	var buffer bytes.Buffer
+
+	// Not verified: only with --http-tls-self-signed and no --tls-ca-file.
+
+	viper.Set(httpTLSSelfSigned.Arg, true)
+	defer viper.Set(httpTLSSelfSigned.Arg, false)
+	client, verified, err := getPlaygroundClient()
+	require.NoError(test, err)
+	require.False(test, verified)
+	require.NoError(test, healthcheckAction(ctx, &buffer, client, server.URL+"/status", ""))
+
+	// Verified against the system's CAs, which did not issue the test certificate.
+
+	viper.Set(httpTLSSelfSigned.Arg, false)
+	client, verified, err = getPlaygroundClient()
+	require.NoError(test, err)
+	require.True(test, verified)
+	require.Error(test, healthcheckAction(ctx, &buffer, client, server.URL+"/status", ""))
+
+	// Verified against --tls-ca-file.
+
+	caFile := filepath.Join(test.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(test, os.WriteFile(caFile, caPEM, 0o600))
+	viper.Set(httpTLSSelfSigned.Arg, true)
+	viper.Set(tlsCAFile.Arg, caFile)
+	defer viper.Set(tlsCAFile.Arg, "")
+	client, verified, err = getPlaygroundClient()
+	require.NoError(test, err)
+	require.True(test, verified)
+	require.NoError(test, healthcheckAction(ctx, &buffer, client, server.URL+"/status", ""))
+}
+
+func Test_snapshotAction(test *testing.T) {
+	ctx := context.TODO()
+	snapshots := getTestSnapshots(test)
+	var buffer bytes.Buffer
+	require.NoError(test, snapshotAction(ctx, &buffer, snapshots, nil, false))
+	require.Equal(test, "No snapshots.\n", buffer.String())
+	require.NoError(test, snapshotAction(ctx, &buffer, snapshots, []string{"first"}, false))
+	require.Contains(test, buffer.String(), "Saved snapshot first (")
+	buffer.Reset()
+	require.NoError(test, snapshotAction(ctx, &buffer, snapshots, nil, false))
+	require.Contains(test, buffer.String(), "NAME")
+	require.Contains(test, buffer.String(), "first")
+	require.NoError(test, snapshotAction(ctx, &buffer, snapshots, []string{"first"}, true))
+	require.Contains(test, buffer.String(), "Deleted snapshot first.\n")
+	err := snapshotAction(ctx, &buffer, snapshots, nil, true)
+	require.ErrorIs(test, err, snapshot.ErrInvalidName)
+	err = snapshotAction(ctx, &buffer, snapshots, []string{"first"}, true)
+	require.ErrorIs(test, err, snapshot.ErrNotFound)
+}
+
+func Test_restoreAction(test *testing.T) {
+	ctx := context.TODO()
+	snapshots := getTestSnapshots(test)
+	var buffer bytes.Buffer
+	_, err := snapshots.Create(ctx, "first")
+	require.NoError(test, err)
+	require.NoError(test, restoreAction(ctx, &buffer, snapshots, "first"))
+	require.Equal(test, "Restored the repository from snapshot first.\n", buffer.String())
+	buffer.Reset()
+	require.NoError(test, restoreAction(ctx, &buffer, snapshots, snapshot.BaselineName))
+	require.Equal(test, "Reset the repository to the baseline.\n", buffer.String())
+	err = restoreAction(ctx, &buffer, snapshots, "missing")
+	require.ErrorIs(test, err, snapshot.ErrNotFound)
+}
+
+func Test_remoteSnapshots(test *testing.T) {
+	ctx := context.TODO()
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"snapshots": [{"name": "first", "size": 1024}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/snapshots/":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"name": "second", "size": 2048}`)
+		case r.URL.Path == "/snapshots/missing/restore":
+			http.Error(w, "snapshot: no such snapshot: missing", http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	remote := &remoteSnapshots{client: server.Client(), token: "secret", url: server.URL + "/snapshots/"}
+	list, err := remote.List(ctx)
+	require.NoError(test, err)
+	require.Len(test, list, 1)
+	require.Equal(test, "first", list[0].Name)
+	created, err := remote.Create(ctx, "second")
+	require.NoError(test, err)
+	require.Equal(test, int64(2048), created.Size)
+	require.NoError(test, remote.Delete(ctx, "first"))
+	require.NoError(test, remote.Restore(ctx, "second"))
+	err = remote.Restore(ctx, "missing")
+	require.ErrorContains(test, err, "no such snapshot: missing")
+	require.Equal(test, []string{
+		"GET /snapshots/ Bearer secret",
+		"POST /snapshots/ Bearer secret",
+		"DELETE /snapshots/first Bearer secret",
+		"POST /snapshots/second/restore Bearer secret",
+		"POST /snapshots/missing/restore Bearer secret",
+	}, requests)
+}
+
+func Test_remoteSnapshots_unavailable(test *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	remote := &remoteSnapshots{client: server.Client(), url: server.URL + "/snapshots/"}
+	_, err := remote.List(context.TODO())
+	require.ErrorIs(test, err, ErrSnapshotsUnavailable)
+}
+
+func Test_getSnapshotter_unverified(test *testing.T) {
+	viper.Set(httpTLSSelfSigned.Arg, true)
+	viper.Set(httpBearerToken.Arg, "secret")
+	defer func() {
+		viper.Set(httpTLSSelfSigned.Arg, false)
+		viper.Set(httpBearerToken.Arg, "")
+	}()
+	var buffer bytes.Buffer
+	_, err := getSnapshotter(context.TODO(), &buffer)
+	require.ErrorIs(test, err, ErrUnverifiedPlayground)
+}
+
+func Test_getGrpcDialOptions_cleartextToken(test *testing.T) {
+	_, _, err := getGrpcDialOptions("grpc://playground.example.com:8261", "loadtoken")
+	require.ErrorIs(test, err, ErrCleartextGrpcToken)
+	_, _, err = getGrpcDialOptions("grpc://playground.example.com:8261", "")
+	require.NoError(test, err)
+	for _, grpcURL := range []string{"grpc://localhost:8261", "grpc://127.0.0.1:8261", "grpc://[::1]:8261"} {
+		_, dialOptions, err := getGrpcDialOptions(grpcURL, "loadtoken")
+		require.NoError(test, err, grpcURL)
+		require.Len(test, dialOptions, 2)
+	}
+}
+
+func Test_isLocalHost(test *testing.T) {
+	require.True(test, isLocalHost("localhost"))
+	require.True(test, isLocalHost("127.0.0.1"))
+	require.True(test, isLocalHost("::1"))
+	require.False(test, isLocalHost("playground.example.com"))
+}
+
+func Test_getSnapshotsURL(test *testing.T) {
+	viper.Set(basePath.Arg, "/playground")
+	defer viper.Set(basePath.Arg, "")
+	require.Regexp(test, `^http://localhost:\d+/playground/snapshots/$`, getSnapshotsURL())
+}
+
+func Test_initializeDatabaseFile(test *testing.T) {
+	ctx := context.TODO()
+	var buffer bytes.Buffer
+	databaseFile := filepath.Join(test.TempDir(), "G2C.db")
+	require.NoError(test, os.WriteFile(databaseFile, []byte("not empty"), 0o600))
+	err := initializeDatabaseFile(ctx, &buffer, getTestSettings(databaseFile, test.TempDir()))
+	require.NoError(test, err)
+	err = initializeDatabaseFile(ctx, &buffer, `{"PIPELINE":{},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@nowhere/tmp/sqlite/G2C.db?mode=memory&cache=shared"}}`)
+	require.NoError(test, err)
+	require.Empty(test, buffer.String())
+}
+
+func Test_initializeDatabaseFile_noSchema(test *testing.T) {
+	ctx := context.TODO()
+	var buffer bytes.Buffer
+	databaseFile := filepath.Join(test.TempDir(), "sqlite", "G2C.db")
+	err := initializeDatabaseFile(ctx, &buffer, getTestSettings(databaseFile, test.TempDir()))
+	require.ErrorIs(test, err, fs.ErrNotExist)
+	require.ErrorContains(test, err, "szcore-schema-sqlite-create.sql")
+	require.NoFileExists(test, databaseFile)
+	require.NoDirExists(test, filepath.Dir(databaseFile))
+	require.Empty(test, buffer.String())
+}
+
+func Test_initializeInMemoryDatabase(test *testing.T) {
+	ctx := context.TODO()
+	databaseFile := filepath.Join(test.TempDir(), "G2C.db")
+	require.NoError(test, initializeInMemoryDatabase(ctx, getTestSettings(databaseFile, test.TempDir())))
+	require.NoFileExists(test, databaseFile)
+	err := initializeInMemoryDatabase(ctx, getTestSettings(databaseFile+"?mode=memory&cache=shared", test.TempDir()))
+	require.ErrorContains(test, err, "szcore-schema-sqlite-create.sql")
+}
+
+func Test_getMissingDir(test *testing.T) {
+	directory := test.TempDir()
+	require.Empty(test, getMissingDir(filepath.Join(directory, "G2C.db")))
+	require.Equal(test, filepath.Join(directory, "a"), getMissingDir(filepath.Join(directory, "a", "b", "G2C.db")))
+}
+
+func Test_saveBaseline(test *testing.T) {
+	ctx := context.TODO()
+	var buffer bytes.Buffer
+	directory := test.TempDir()
+	databaseFile := filepath.Join(directory, "G2C.db")
+	snapshots := &snapshot.BasicSnapshots{DatabaseFile: databaseFile, Directory: filepath.Join(directory, "snapshots")}
+	err := saveBaseline(ctx, &buffer, getTestSettings(databaseFile, test.TempDir()), snapshots)
+	require.NoError(test, err, "reported, not returned")
+	require.Contains(test, buffer.String(), "Could not save the baseline")
+	require.Contains(test, buffer.String(), "szcore-schema-sqlite-create.sql")
+	require.ErrorIs(test, snapshots.Restore(ctx, snapshot.BaselineName), snapshot.ErrNoBaseline)
+}
+
+func Test_getDatabaseFileSettings(test *testing.T) {
+	baselineSettings, err := getDatabaseFileSettings(`{"PIPELINE":{"RESOURCEPATH":"/opt/senzing/er/resources"},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@/tmp/sqlite/G2C.db"}}`, "/tmp/sqlite/snapshots/baseline.db.partial")
+	require.NoError(test, err)
+	require.JSONEq(test, `{"PIPELINE":{"RESOURCEPATH":"/opt/senzing/er/resources"},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@/tmp/sqlite/snapshots/baseline.db.partial"}}`, baselineSettings)
+	databaseFile, err := snapshot.DatabaseFile(context.TODO(), baselineSettings)
+	require.NoError(test, err)
+	require.Equal(test, "/tmp/sqlite/snapshots/baseline.db.partial", databaseFile)
+	_, err = getDatabaseFileSettings("not JSON", "/tmp/baseline.db")
+	require.Error(test, err)
+}
+
+func Test_getSnapshots(test *testing.T) {
+	ctx := context.TODO()
+	snapshots, err := getSnapshots(ctx, `{"PIPELINE":{},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@/tmp/sqlite/G2C.db"}}`, nil)
+	require.NoError(test, err)
+	require.Equal(test, "/tmp/sqlite/G2C.db", snapshots.DatabaseFile)
+	require.Equal(test, "/tmp/sqlite/snapshots", snapshots.Directory)
+	_, err = getSnapshots(ctx, `{"PIPELINE":{},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@nowhere/tmp/sqlite/G2C.db?mode=memory&cache=shared"}}`, nil)
+	require.ErrorIs(test, err, snapshot.ErrUnsupportedDatabase)
+}
+
+func Test_getDefaultAllowedHostnames(test *testing.T) {
+	allowedHostnames := getDefaultAllowedHostnames()
+	require.Equal(test, "localhost", allowedHostnames[0])
+	require.Contains(test, allowedHostnames, "127.0.0.1")
+}
+
+func Test_getSupervisedPrograms(test *testing.T) {
+	programsFile := filepath.Join(test.TempDir(), "programs.json")
+	require.NoError(test, os.WriteFile(programsFile, []byte(`{"programs": [{"name": "extra", "command": "true"}]}`), 0600))
+	viper.Set(basePath.Arg, "/playground")
+	viper.Set(superviseJupyterLab.Arg, true)
+	viper.Set(supervisorProgramsFile.Arg, programsFile)
+	defer func() {
+		viper.Set(basePath.Arg, "")
+		viper.Set(superviseJupyterLab.Arg, false)
+		viper.Set(supervisorProgramsFile.Arg, "")
+	}()
+	programs, err := getSupervisedPrograms()
+	require.NoError(test, err)
+	require.Len(test, programs, 2)
+	require.Equal(test, "jupyter-lab", programs[0].Name)
+	require.Contains(test, programs[0].Arguments, "--ServerApp.base_url=/playground/jupyter")
+	require.Equal(test, "extra", programs[1].Name)
+}
+
+func Test_getSupervisedPrograms_goRestAPI(test *testing.T) {
+	viper.Set(supervisePocServer.Arg, true)
+	defer viper.Set(supervisePocServer.Arg, false)
+	programs, err := getSupervisedPrograms()
+	require.NoError(test, err)
+	require.Len(test, programs, 1)
+	require.Equal(test, "senzing-poc-server", programs[0].Name)
+	viper.Set(goRestAPI.Arg, true)
+	defer viper.Set(goRestAPI.Arg, false)
+	programs, err = getSupervisedPrograms()
+	require.NoError(test, err)
+	require.Empty(test, programs)
+}
+
+func Test_getAuthentication(test *testing.T) {
+	playgroundAuthentication, err := getAuthentication()
+	require.NoError(test, err)
+	require.Nil(test, playgroundAuthentication, "no methods configured")
+
+	tokensFile := filepath.Join(test.TempDir(), "tokens")
+	require.NoError(test, os.WriteFile(tokensFile, []byte("ci:0123456789abcdef\n"), 0600))
+	viper.Set(authAdmins.Arg, []string{"root"})
+	viper.Set(authLoaders.Arg, []string{"ci", "root"})
+	viper.Set(authTokensFile.Arg, tokensFile)
+	defer func() {
+		viper.Set(authAdmins.Arg, []string{})
+		viper.Set(authDefaultRole.Arg, authentication.RoleViewer)
+		viper.Set(authLoaders.Arg, []string{})
+		viper.Set(authTokensFile.Arg, "")
+	}()
+	playgroundAuthentication, err = getAuthentication()
+	require.NoError(test, err)
+	require.Equal(test, authentication.RoleViewer, playgroundAuthentication.DefaultRole)
+	require.Equal(test, map[string]string{"ci": authentication.RoleLoader, "root": authentication.RoleAdmin}, playgroundAuthentication.Roles)
+
+	viper.Set(authDefaultRole.Arg, "superuser")
+	_, err = getAuthentication()
+	require.ErrorIs(test, err, authentication.ErrUnknownRole)
+}
+
+func Test_generateCertificatesAction(test *testing.T) {
+	var buffer bytes.Buffer
+	dir := test.TempDir()
+	err := generateCertificatesAction(&buffer, dir, []string{"localhost"})
+	require.NoError(test, err)
+	require.FileExists(test, filepath.Join(dir, "ca.pem"))
+	require.FileExists(test, filepath.Join(dir, "client-key.pem"))
+	err = generateCertificatesAction(&buffer, dir, []string{"localhost"})
+	require.ErrorIs(test, err, fs.ErrExist)
+}
+
 func Test_docsAction_badDir(test *testing.T) {
 	var buffer bytes.Buffer
 	badDir := "/tmp/no/directory/exists"
 	err := docsAction(&buffer, badDir)
 	require.Error(test, err)
 }
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// A BasicSnapshots of a copy of the test repository, whose baseline is the test repository.
+func getTestSnapshots(test *testing.T) *snapshot.BasicSnapshots {
+	baseline, err := os.ReadFile("../testdata/sqlite/G2C-with-config.db")
+	require.NoError(test, err)
+	directory := test.TempDir()
+	databaseFile := filepath.Join(directory, "G2C.db")
+	require.NoError(test, os.WriteFile(databaseFile, baseline, 0o600))
+	result := &snapshot.BasicSnapshots{
+		DatabaseFile: databaseFile,
+		Directory:    filepath.Join(directory, "snapshots"),
+	}
+	_, err = result.SaveBaseline(context.TODO(), func(ctx context.Context, file string) error {
+		_ = ctx
+		return os.WriteFile(file, baseline, 0o600)
+	})
+	require.NoError(test, err)
+	return result
+}
+
+// Senzing engine settings for a SQLite database file, with resources in resourcePath.
+func getTestSettings(databaseFile string, resourcePath string) string {
+	return fmt.Sprintf(`{"PIPELINE":{"RESOURCEPATH":%q},"SQL":{"BACKEND":"SQL","CONNECTION":"sqlite3://na:na@%s"}}`, resourcePath, databaseFile)
+}
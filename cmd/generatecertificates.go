@@ -0,0 +1,113 @@
+/*
+ */
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/senzing-garage/playground/selfsigned"
+	"github.com/spf13/cobra"
+)
+
+// generateCertificatesCmd represents the generate-certificates command
+var generateCertificatesCmd = &cobra.Command{
+	Use:   "generate-certificates",
+	Short: "Generate a local CA plus server and client certificates for the gRPC server",
+	Long: `Generate a local certificate authority (CA), a server certificate, and a client certificate,
+all signed by the CA, for serving gRPC over TLS or mutual TLS.  For local use only.
+
+Existing files are never overwritten.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		dir, err := cmd.Flags().GetString("dir")
+		if err != nil {
+			return err
+		}
+		hostnames, err := cmd.Flags().GetStringSlice("hostnames")
+		if err != nil {
+			return err
+		}
+		return generateCertificatesAction(os.Stdout, dir, hostnames)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(generateCertificatesCmd)
+	generateCertificatesCmd.Flags().StringP("dir", "d", ".", "Destination directory for certificates")
+	generateCertificatesCmd.Flags().StringSlice("hostnames", getDefaultAllowedHostnames(), "Hostnames and IP addresses the server certificate is valid for")
+}
+
+func generateCertificatesAction(out io.Writer, dir string, hostnames []string) error {
+	caPEM, caKeyPEM, err := selfsigned.GenerateCertificateAuthority("Senzing Playground CA")
+	if err != nil {
+		return err
+	}
+	serverPEM, serverKeyPEM, err := selfsigned.GenerateSignedBy(caPEM, caKeyPEM, "server", hostnames)
+	if err != nil {
+		return err
+	}
+	clientPEM, clientKeyPEM, err := selfsigned.GenerateSignedBy(caPEM, caKeyPEM, "client", []string{})
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name     string
+		contents []byte
+	}{
+		{name: "ca.pem", contents: caPEM},
+		{name: "ca-key.pem", contents: caKeyPEM},
+		{name: "server.pem", contents: serverPEM},
+		{name: "server-key.pem", contents: serverKeyPEM},
+		{name: "client.pem", contents: clientPEM},
+		{name: "client-key.pem", contents: clientKeyPEM},
+	}
+	for _, file := range files {
+		if err := writeNewFile(filepath.Join(dir, file.name), file.contents); err != nil {
+			return err
+		}
+	}
+
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, `Certificates successfully created in %[1]s
+
+To serve gRPC over mutual TLS, set:
+    %[2]s=%[1]s/server.pem
+    %[3]s=%[1]s/server-key.pem
+    %[4]s=%[1]s/ca.pem
+
+To show matching client code in the console, also set:
+    %[5]s=%[1]s/ca.pem
+    %[6]s=%[1]s/client.pem
+    %[7]s=%[1]s/client-key.pem
+`,
+		absoluteDir,
+		grpcTLSCertFile.Envar,
+		grpcTLSKeyFile.Envar,
+		grpcTLSClientCAFile.Envar,
+		grpcTLSServerCAFile.Envar,
+		grpcTLSClientCertFile.Envar,
+		grpcTLSClientKeyFile.Envar,
+	)
+	return err
+}
+
+// Write a file, failing rather than overwriting an existing one.
+func writeNewFile(filename string, contents []byte) error {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(contents)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
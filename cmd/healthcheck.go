@@ -0,0 +1,166 @@
+/*
+ */
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/playground/httpserver"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrNotReady is returned by the healthcheck command when the playground is not ready.
+var ErrNotReady = errors.New("playground is not ready")
+
+var healthcheckContextVariables = []option.ContextVariable{
+	basePath,
+	httpBearerToken,
+	httpTLSCertFile,
+	httpTLSSelfSigned,
+	option.Configuration,
+	option.HTTPPort,
+	playgroundHost,
+	tlsCAFile,
+}
+
+// healthcheckCmd represents the healthcheck command
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck",
+	Short: "Report whether the playground is ready",
+	Long: `Ask the playground on --playground-host for its status and list the state of each service.
+Exit with a non-zero status unless it is ready: the gRPC server, the Senzing engine,
+and the database are up.
+
+Use the same --http-port, --base-path, and HTTPS settings as the running playground.
+When it requires authentication, set --http-bearer-token to list the services;
+without it, only whether the playground is ready is reported.
+With HTTPS, its certificate is verified against --tls-ca-file or the system's CAs,
+except a --http-tls-self-signed one when no --tls-ca-file is given.
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, healthcheckContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		cmd.SilenceUsage = true
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+		client, verified, err := getPlaygroundClient()
+		if err != nil {
+			return err
+		}
+		token := viper.GetString(httpBearerToken.Arg)
+		if !verified && len(token) > 0 {
+			return ErrUnverifiedPlayground
+		}
+		return healthcheckAction(ctx, os.Stdout, client, getHealthcheckURL(), token)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(healthcheckCmd)
+	cmdhelper.Init(healthcheckCmd, healthcheckContextVariables)
+}
+
+/*
+Report the status of the playground at statusURL, sending token, if any, as a bearer token.
+Without a token, a playground that requires authentication only reports its readiness, at "/readyz".
+*/
+func healthcheckAction(ctx context.Context, out io.Writer, client *http.Client, statusURL string, token string) error {
+	status := httpserver.StatusResponse{}
+	statusCode, err := getHealthcheckJSON(ctx, client, statusURL, token, &status)
+	if statusCode == http.StatusUnauthorized && len(token) == 0 {
+		readiness := httpserver.ReadinessResponse{}
+		_, err = getHealthcheckJSON(ctx, client, strings.TrimSuffix(statusURL, "status")+"readyz", "", &readiness)
+		status.Ready, status.Status = readiness.Ready, readiness.Status
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "Status: %s\n", status.Status)
+	if err != nil {
+		return err
+	}
+	for _, service := range status.Services {
+		_, err = fmt.Fprintf(out, "    %-20s %-9s %8.1fms  %s\n", service.Name, service.State, service.LatencyMilliseconds, service.LastError)
+		if err != nil {
+			return err
+		}
+	}
+	if !status.Ready {
+		return fmt.Errorf("%w: %s", ErrNotReady, status.Status)
+	}
+	return nil
+}
+
+// GET a health endpoint and decode its response into result.  "/readyz" answers http.StatusServiceUnavailable when not ready.
+func getHealthcheckJSON(ctx context.Context, client *http.Client, requestURL string, token string, result any) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusServiceUnavailable {
+		return response.StatusCode, fmt.Errorf("GET %s: %s", requestURL, response.Status)
+	}
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return response.StatusCode, fmt.Errorf("GET %s: %w", requestURL, err)
+	}
+	return response.StatusCode, nil
+}
+
+/*
+An HTTP client for the playground.  Its certificate is verified against --tls-ca-file, or the system's CAs.
+A --http-tls-self-signed certificate is generated when the playground starts, so without --tls-ca-file
+there is nothing to verify it against; it is then accepted as is, and the second value returned is false.
+*/
+func getPlaygroundClient() (*http.Client, bool, error) {
+	tlsConfig, err := getClientTLSConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	verified := true
+	if viper.GetBool(httpTLSSelfSigned.Arg) && len(viper.GetString(tlsCAFile.Arg)) == 0 {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+		verified = false
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, verified, nil
+}
+
+// URL of the status endpoint of the playground on --playground-host.
+func getHealthcheckURL() string {
+	host := viper.GetString(playgroundHost.Arg)
+	if len(host) == 0 {
+		host = "localhost"
+	}
+	scheme := "http"
+	if len(viper.GetString(httpTLSCertFile.Arg)) > 0 || viper.GetBool(httpTLSSelfSigned.Arg) {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(viper.GetInt(option.HTTPPort.Arg))), path.Join("/", viper.GetString(basePath.Arg), "status"))
+}
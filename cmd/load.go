@@ -0,0 +1,268 @@
+/*
+ */
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/go-cmdhelping/settings"
+	"github.com/senzing-garage/go-sdk-abstract-factory/szfactorycreator"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// ErrNoCACertificates is returned by commands connecting to a playground when --tls-ca-file holds no PEM certificates.
+var ErrNoCACertificates = errors.New("no PEM certificates found in --tls-ca-file")
+
+// ErrInvalidGrpcURL is returned by the load command for a --grpc-url it cannot dial.
+var ErrInvalidGrpcURL = errors.New("invalid gRPC URL: use grpc://host:port, or grpcs://host:port for TLS")
+
+// ErrCleartextGrpcToken is returned by the load command rather than send --grpc-bearer-token unencrypted to another machine.
+var ErrCleartextGrpcToken = errors.New("--grpc-bearer-token is only sent over grpc:// to this machine: use grpcs:// for another host")
+
+var grpcBearerToken = option.ContextVariable{
+	Arg:     "grpc-bearer-token",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_BEARER_TOKEN", ""),
+	Envar:   "SENZING_TOOLS_GRPC_BEARER_TOKEN",
+	Help:    "Token sent to the Senzing gRPC service at --grpc-url, when it requires authentication.  It is only sent over grpcs://, or over grpc:// to this machine [%s]",
+	Type:    optiontype.String,
+}
+
+var loadContextVariables = []option.ContextVariable{
+	option.Configuration,
+	option.DatabaseURL,
+	option.EngineInstanceName,
+	option.EngineLogLevel,
+	option.EngineSettings,
+	grpcBearerToken,
+	option.GrpcURL,
+	tlsCAFile,
+	tlsCertFile,
+	tlsKeyFile,
+}
+
+// loadCmd represents the load command
+var loadCmd = &cobra.Command{
+	Use:   "load FILE",
+	Short: "Load records from a JSON Lines file",
+	Long: `Add the records of a JSON Lines file, one JSON record with DATA_SOURCE and RECORD_ID per line,
+to the Senzing repository.  Data sources the records name are registered first.
+
+Records are added by the embedded Senzing engine, using --database-url or --engine-settings,
+or by the Senzing gRPC service at --grpc-url, such as a running playground.  When it requires
+authentication, the load command needs the loader role.  Over TLS, set --tls-ca-file to verify
+a playground using generate-certificates' certificates, and --tls-cert-file and --tls-key-file
+when it requires client certificates.
+
+Records that cannot be added are written, with the error, to the failures file.  If the load
+is interrupted, run the same command with --resume to continue after the last line loaded.
+
+Examples:
+    playground load customers.jsonl
+    playground load --grpc-url grpc://localhost:8261 --threads 8 customers.jsonl
+`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, loadContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		recordLoader, err := getLoader(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		factory, closeFactory, err := getLoadFactory(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = closeFactory()
+		}()
+		recordLoader.Factory = factory
+		return loadAction(ctx, os.Stdout, recordLoader)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(loadCmd)
+	cmdhelper.Init(loadCmd, loadContextVariables)
+	loadCmd.Flags().String("checkpoint-file", "", "Path of the file recording the last line loaded. Default: FILE.checkpoint")
+	loadCmd.Flags().String("failures-file", "", "Path of the JSON Lines file of records that could not be added. Default: FILE.failures")
+	loadCmd.Flags().Duration("progress-interval", loader.DefaultProgressInterval, "Time between progress reports")
+	loadCmd.Flags().Bool("resume", false, "Continue after the last line in the checkpoint file, keeping the failures of the lines up to it")
+	loadCmd.Flags().Int("threads", loader.DefaultThreads, "Number of records added in parallel")
+}
+
+func loadAction(ctx context.Context, out io.Writer, recordLoader *loader.BasicLoader) error {
+	recordLoader.Output = out
+	_, err := recordLoader.Load(ctx)
+	if errors.Is(err, loader.ErrInterrupted) {
+		return fmt.Errorf("%w; run again with --resume to continue", err)
+	}
+	return err
+}
+
+// The loader the flags of the load command describe, for a file.  Its Factory is not set.
+func getLoader(cmd *cobra.Command, filename string) (*loader.BasicLoader, error) {
+	result := &loader.BasicLoader{
+		CheckpointFile: filename + ".checkpoint",
+		FailuresFile:   filename + ".failures",
+		InputFile:      filename,
+	}
+	checkpointFile, err := cmd.Flags().GetString("checkpoint-file")
+	if err != nil {
+		return nil, err
+	}
+	if len(checkpointFile) > 0 {
+		result.CheckpointFile = checkpointFile
+	}
+	failuresFile, err := cmd.Flags().GetString("failures-file")
+	if err != nil {
+		return nil, err
+	}
+	if len(failuresFile) > 0 {
+		result.FailuresFile = failuresFile
+	}
+	result.ProgressInterval, err = cmd.Flags().GetDuration("progress-interval")
+	if err != nil {
+		return nil, err
+	}
+	result.Resume, err = cmd.Flags().GetBool("resume")
+	if err != nil {
+		return nil, err
+	}
+	result.Threads, err = cmd.Flags().GetInt("threads")
+	return result, err
+}
+
+/*
+The Senzing factory records are loaded through: a gRPC connection to --grpc-url, if set;
+otherwise the embedded Senzing engine, configured like the playground's.
+Also returns a function that releases the factory.
+*/
+func getLoadFactory(ctx context.Context) (senzing.SzAbstractFactory, func() error, error) {
+	grpcURL := viper.GetString(option.GrpcURL.Arg)
+	if len(grpcURL) > 0 {
+		target, dialOptions, err := getGrpcDialOptions(grpcURL, viper.GetString(grpcBearerToken.Arg))
+		if err != nil {
+			return nil, nil, err
+		}
+		connection, err := grpc.NewClient(target, dialOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		factory, err := szfactorycreator.CreateGrpcAbstractFactory(connection)
+		return factory, connection.Close, err
+	}
+
+	// Set default value for SENZING_TOOLS_DATABASE_URL.
+
+	_, isSet := os.LookupEnv("SENZING_TOOLS_DATABASE_URL")
+	if !isSet {
+		err := os.Setenv("SENZING_TOOLS_DATABASE_URL", SenzingToolsDatabaseURL)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	senzingSettings, err := settings.BuildAndVerifySettings(ctx, viper.GetViper())
+	if err != nil {
+		return nil, nil, err
+	}
+	factory, err := szfactorycreator.CreateCoreAbstractFactory(
+		viper.GetString(option.EngineInstanceName.Arg),
+		senzingSettings,
+		viper.GetInt64(option.EngineLogLevel.Arg),
+		senzing.SzInitializeWithDefaultConfiguration,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return factory, func() error {
+		return factory.Destroy(context.WithoutCancel(ctx))
+	}, nil
+}
+
+/*
+The target and dial options for a gRPC URL: "grpc://host:port", or "grpcs://host:port" for TLS,
+configured by --tls-ca-file, --tls-cert-file, and --tls-key-file.
+With a token, each call carries it as a bearer token; over grpc://, only to this machine.
+*/
+func getGrpcDialOptions(grpcURL string, token string) (string, []grpc.DialOption, error) {
+	parsedURL, err := url.Parse(grpcURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidGrpcURL, err)
+	}
+	if len(parsedURL.Host) == 0 {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidGrpcURL, grpcURL)
+	}
+	result := []grpc.DialOption{}
+	switch parsedURL.Scheme {
+	case "grpc":
+		if len(token) > 0 && !isLocalHost(parsedURL.Hostname()) {
+			return "", nil, ErrCleartextGrpcToken
+		}
+		result = append(result, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	case "grpcs":
+		tlsConfig, err := getClientTLSConfig()
+		if err != nil {
+			return "", nil, err
+		}
+		result = append(result, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	default:
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidGrpcURL, grpcURL)
+	}
+	if len(token) > 0 {
+		result = append(result, grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, request, reply any, connection *grpc.ClientConn, invoker grpc.UnaryInvoker, options ...grpc.CallOption) error {
+			return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), method, request, reply, connection, options...)
+		}))
+	}
+	return parsedURL.Host, result, nil
+}
+
+/*
+The TLS configuration of commands connecting to a playground: verifying it against --tls-ca-file,
+or the system's CAs, and presenting --tls-cert-file and --tls-key-file, if set.
+*/
+func getClientTLSConfig() (*tls.Config, error) {
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+	caFile := viper.GetString(tlsCAFile.Arg)
+	if len(caFile) > 0 {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		result.RootCAs = x509.NewCertPool()
+		if !result.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("%w: %s", ErrNoCACertificates, caFile)
+		}
+	}
+	certFile := viper.GetString(tlsCertFile.Arg)
+	if len(certFile) > 0 {
+		certificate, err := tls.LoadX509KeyPair(certFile, viper.GetString(tlsKeyFile.Arg))
+		if err != nil {
+			return nil, err
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+	return result, nil
+}
@@ -0,0 +1,259 @@
+/*
+ */
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/spf13/cobra"
+)
+
+// ErrUnknownDelimiter is returned by the map command for a file that is not named *.csv or *.tsv, without --delimiter.
+var ErrUnknownDelimiter = errors.New("unknown delimiter: name the file *.csv or *.tsv, or set --delimiter")
+
+// Number of mapped records the map command shows with --dry-run.
+const mapSamples = 5
+
+// What the flags of the map command ask for.
+type mapOptions struct {
+	comma       rune
+	dataSource  string
+	dryRun      bool
+	filename    string
+	mappingFile string
+	outputFile  string
+	suggest     bool
+}
+
+var mapContextVariables = []option.ContextVariable{
+	option.Configuration,
+}
+
+// mapCmd represents the map command
+var mapCmd = &cobra.Command{
+	Use:   "map FILE",
+	Short: "Map the columns of a CSV or TSV file to Senzing attributes",
+	Long: `Write the rows of a CSV or TSV file with a header row as JSON Lines, ready for "playground load",
+mapping its columns to Senzing attributes such as NAME_FIRST, ADDR_LINE1, or SSN_NUMBER.
+
+The mapping is read from the mapping file, JSON naming the attribute of each column.
+If there is no mapping file, or with --suggest, one is suggested from the column names
+and saved, and the mapped records are shown without writing them.  Edit the mapping file,
+with --dry-run to check it, then run again to write the records.
+
+Records get the mapping's data source, unless a column maps to DATA_SOURCE, and the number
+of their line as their RECORD_ID, unless a column maps to RECORD_ID.
+
+Examples:
+    playground map customers.csv
+    playground map --dry-run --data-source CUSTOMERS customers.csv
+    playground map customers.csv && playground load customers.jsonl
+`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, mapContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		options, err := getMapOptions(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		return mapAction(os.Stdout, options)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(mapCmd)
+	cmdhelper.Init(mapCmd, mapContextVariables)
+	mapCmd.Flags().String("data-source", "", "Data source of the records, replacing the one in the mapping file")
+	mapCmd.Flags().String("delimiter", "", "Field delimiter: a character, or \"tab\". Default: from the file name, *.csv or *.tsv")
+	mapCmd.Flags().Bool("dry-run", false, "Show the mapping and the first mapped records, without writing the output file")
+	mapCmd.Flags().String("mapping-file", "", "Path of the mapping file. Default: FILE.mapping.json")
+	mapCmd.Flags().String("output-file", "", "Path of the JSON Lines file written. Default: FILE, named *.jsonl")
+	mapCmd.Flags().Bool("suggest", false, "Replace the mapping file with one suggested from the column names")
+}
+
+func mapAction(out io.Writer, options mapOptions) error {
+	if options.comma == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownDelimiter, options.filename)
+	}
+	header, err := readMapHeader(options.filename, options.comma)
+	if err != nil {
+		return err
+	}
+
+	// Read the mapping, or suggest one.
+
+	var mapping loader.Mapping
+	_, err = os.Stat(options.mappingFile)
+	isSuggested := options.suggest || errors.Is(err, os.ErrNotExist)
+	if isSuggested {
+		mapping = loader.SuggestMapping(options.filename, header)
+	} else {
+		mapping, err = loader.ReadMapping(options.mappingFile)
+		if err != nil && !(errors.Is(err, loader.ErrMissingDataSource) && len(options.dataSource) > 0) {
+			return err
+		}
+	}
+	if len(options.dataSource) > 0 {
+		mapping.DataSource = options.dataSource
+	}
+	report := &strings.Builder{}
+	if isSuggested {
+		err = loader.WriteMapping(options.mappingFile, mapping)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(report, "Saved a mapping suggested from the column names to %s.  Check it, then run again to write the records.\n\n", options.mappingFile)
+	}
+	writeMapping(report, mapping, header)
+	_, err = io.WriteString(out, report.String())
+	if err != nil {
+		return err
+	}
+
+	// Map the records.  A dry run writes them to a temporary file, to be counted and shown.
+
+	outputFile := options.outputFile
+	isDryRun := options.dryRun || isSuggested
+	if isDryRun {
+		temporaryFile, err := os.CreateTemp("", "playground-map-*.jsonl")
+		if err != nil {
+			return err
+		}
+		outputFile = temporaryFile.Name()
+		defer os.Remove(outputFile)
+		err = temporaryFile.Close()
+		if err != nil {
+			return err
+		}
+	}
+	err = convertMapFile(options.filename, outputFile, options.comma, mapping)
+	if err != nil {
+		return err
+	}
+	samples := 0
+	if isDryRun {
+		samples = mapSamples
+	}
+	analysis, err := loader.Analyze(outputFile, samples)
+	if err != nil {
+		return err
+	}
+	report.Reset()
+	dataSources := []string{}
+	for _, count := range analysis.DataSources {
+		dataSources = append(dataSources, fmt.Sprintf("%s: %d", count.Name, count.Count))
+	}
+	fmt.Fprintf(report, "\n%d records (%s).\n", analysis.Records, strings.Join(dataSources, ", "))
+	if analysis.Invalid > 0 {
+		fmt.Fprintf(report, "%d rows have no DATA_SOURCE or RECORD_ID, and will fail to load.\n", analysis.Invalid)
+	}
+	for _, sample := range analysis.Samples {
+		fmt.Fprintf(report, "%s\n", sample)
+	}
+	if !isDryRun {
+		fmt.Fprintf(report, "Wrote them to %s.  Load them with: playground load %s\n", outputFile, outputFile)
+	}
+	_, err = io.WriteString(out, report.String())
+	return err
+}
+
+// The options the flags of the map command describe, for a file.
+func getMapOptions(cmd *cobra.Command, filename string) (mapOptions, error) {
+	result := mapOptions{
+		comma:       loader.CSVComma(filename),
+		filename:    filename,
+		mappingFile: filename + ".mapping.json",
+		outputFile:  strings.TrimSuffix(filename, filepath.Ext(filename)) + ".jsonl",
+	}
+	delimiter, err := cmd.Flags().GetString("delimiter")
+	if err != nil {
+		return result, err
+	}
+	switch {
+	case strings.EqualFold(delimiter, "tab"):
+		result.comma = '\t'
+	case utf8.RuneCountInString(delimiter) == 1:
+		result.comma, _ = utf8.DecodeRuneInString(delimiter)
+	case len(delimiter) > 0:
+		return result, fmt.Errorf("%w: %q", ErrUnknownDelimiter, delimiter)
+	}
+	result.dataSource, err = cmd.Flags().GetString("data-source")
+	if err != nil {
+		return result, err
+	}
+	result.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return result, err
+	}
+	mappingFile, err := cmd.Flags().GetString("mapping-file")
+	if err != nil {
+		return result, err
+	}
+	if len(mappingFile) > 0 {
+		result.mappingFile = mappingFile
+	}
+	outputFile, err := cmd.Flags().GetString("output-file")
+	if err != nil {
+		return result, err
+	}
+	if len(outputFile) > 0 {
+		result.outputFile = outputFile
+	}
+	result.suggest, err = cmd.Flags().GetBool("suggest")
+	return result, err
+}
+
+// Write the mapped rows of a CSV file to a file of JSON Lines, replacing it.
+func convertMapFile(filename string, outputFile string, comma rune, mapping loader.Mapping) error {
+	input, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	output, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+	_, err = loader.ConvertCSV(input, output, comma, &mapping)
+	return errors.Join(err, output.Close())
+}
+
+func readMapHeader(filename string, comma rune) ([]string, error) {
+	input, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	return loader.ReadCSVHeader(input, comma)
+}
+
+// Describe the attribute of each column, and the columns the mapping and the file disagree on.
+func writeMapping(report *strings.Builder, mapping loader.Mapping, header []string) {
+	writer := tabwriter.NewWriter(report, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "COLUMN\tATTRIBUTE")
+	for _, column := range mapping.Columns {
+		fmt.Fprintf(writer, "%s\t%s\n", column.Name, orDash(column.Attribute))
+	}
+	_ = writer.Flush()
+	fmt.Fprintf(report, "Data source: %s\n", orDash(mapping.DataSource))
+	unmapped, missing := mapping.Compare(header)
+	if len(unmapped) > 0 {
+		fmt.Fprintf(report, "Columns not in the mapping, left out: %s\n", strings.Join(unmapped, ", "))
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(report, "Mapped columns missing from the file: %s\n", strings.Join(missing, ", "))
+	}
+}
@@ -4,23 +4,41 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net"
 	"os"
-	"sync"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
 	"github.com/senzing-garage/go-cmdhelping/option"
 	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
 	"github.com/senzing-garage/go-cmdhelping/settings"
+	"github.com/senzing-garage/go-databasing/dbhelper"
+	"github.com/senzing-garage/go-helpers/settingsparser"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-rest-api-service/senzingrestservice"
+	"github.com/senzing-garage/init-database/initializer"
+	"github.com/senzing-garage/playground/audit"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/playground/grpcserver"
 	"github.com/senzing-garage/playground/httpserver"
-	"github.com/senzing-garage/serve-grpc/grpcserver"
+	"github.com/senzing-garage/playground/metrics"
+	"github.com/senzing-garage/playground/observing"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/senzing-garage/playground/supervisor"
+	"github.com/senzing-garage/playground/tracing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -30,11 +48,239 @@ const (
 A server supporting the following services:
     - HTTP: Senzing API server
     - HTTP: Swagger UI
+    - HTTP: Connect protocol for the gRPC services
     - HTTP: Xterm
     - gRPC:
     `
 )
 
+// Bytes in a megabyte, for options given in megabytes.
+const megabyte = 1024 * 1024
+
+var apiURLRoutePrefix = option.ContextVariable{
+	Arg:     "api-url-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_API_URL_ROUTE_PREFIX", "api"),
+	Envar:   "SENZING_TOOLS_API_URL_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of the Senzing REST API [%s]",
+	Type:    optiontype.String,
+}
+
+var auditFile = option.ContextVariable{
+	Arg:     "audit-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUDIT_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUDIT_FILE",
+	Help:    "Path of the audit log of data-changing operations and xterm sessions. If empty, nothing is audited [%s]",
+	Type:    optiontype.String,
+}
+
+var auditMaxBackups = option.ContextVariable{
+	Arg:     "audit-max-backups",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_AUDIT_MAX_BACKUPS", audit.DefaultMaxBackups),
+	Envar:   "SENZING_TOOLS_AUDIT_MAX_BACKUPS",
+	Help:    "Number of rotated audit log files kept [%s]",
+	Type:    optiontype.Int,
+}
+
+var auditMaxSizeInMegabytes = option.ContextVariable{
+	Arg:     "audit-max-size-in-megabytes",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_AUDIT_MAX_SIZE_IN_MEGABYTES", audit.DefaultMaxSize/megabyte),
+	Envar:   "SENZING_TOOLS_AUDIT_MAX_SIZE_IN_MEGABYTES",
+	Help:    "Size at which the audit log is rotated [%s]",
+	Type:    optiontype.Int,
+}
+
+var authAdmins = option.ContextVariable{
+	Arg:     "auth-admins",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_AUTH_ADMINS",
+	Help:    "Comma-delimited list of users given the admin role [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var authDefaultRole = option.ContextVariable{
+	Arg:     "auth-default-role",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_DEFAULT_ROLE", authentication.RoleViewer),
+	Envar:   "SENZING_TOOLS_AUTH_DEFAULT_ROLE",
+	Help:    "Role of users not given another one: viewer, loader, or admin [%s]",
+	Type:    optiontype.String,
+}
+
+var authLoaders = option.ContextVariable{
+	Arg:     "auth-loaders",
+	Default: []string{},
+	Envar:   "SENZING_TOOLS_AUTH_LOADERS",
+	Help:    "Comma-delimited list of users given the loader role [%s]",
+	Type:    optiontype.StringSlice,
+}
+
+var authOidcAudience = option.ContextVariable{
+	Arg:     "auth-oidc-audience",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_OIDC_AUDIENCE", ""),
+	Envar:   "SENZING_TOOLS_AUTH_OIDC_AUDIENCE",
+	Help:    "Audience, usually the client ID, that OpenID Connect tokens must be issued for. If empty, any [%s]",
+	Type:    optiontype.String,
+}
+
+var authOidcIssuer = option.ContextVariable{
+	Arg:     "auth-oidc-issuer",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_OIDC_ISSUER", ""),
+	Envar:   "SENZING_TOOLS_AUTH_OIDC_ISSUER",
+	Help:    "URL of an OpenID Connect provider whose bearer tokens are accepted. Example: https://accounts.example.com [%s]",
+	Type:    optiontype.String,
+}
+
+var authOidcRoleClaim = option.ContextVariable{
+	Arg:     "auth-oidc-role-claim",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_OIDC_ROLE_CLAIM", ""),
+	Envar:   "SENZING_TOOLS_AUTH_OIDC_ROLE_CLAIM",
+	Help:    "Claim of OpenID Connect tokens naming the user's role, as a string or a list. Example: groups [%s]",
+	Type:    optiontype.String,
+}
+
+var authOidcUsernameClaim = option.ContextVariable{
+	Arg:     "auth-oidc-username-claim",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_OIDC_USERNAME_CLAIM", authentication.DefaultUsernameClaim),
+	Envar:   "SENZING_TOOLS_AUTH_OIDC_USERNAME_CLAIM",
+	Help:    "Claim of OpenID Connect tokens used as the user name. Example: email [%s]",
+	Type:    optiontype.String,
+}
+
+var authTokensFile = option.ContextVariable{
+	Arg:     "auth-tokens-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_TOKENS_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUTH_TOKENS_FILE",
+	Help:    "Path of a file of \"name:token\" lines. Its tokens are accepted as bearer tokens [%s]",
+	Type:    optiontype.String,
+}
+
+var authUsersFile = option.ContextVariable{
+	Arg:     "auth-users-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTH_USERS_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUTH_USERS_FILE",
+	Help:    "Path of a file of \"name:bcrypt-hash\" lines, as written by \"htpasswd -B\". Its users may sign in with a password [%s]",
+	Type:    optiontype.String,
+}
+
+var basePath = option.ContextVariable{
+	Arg:     "base-path",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_BASE_PATH", ""),
+	Envar:   "SENZING_TOOLS_BASE_PATH",
+	Help:    "URL path under which every service is served. Example: /playground [%s]",
+	Type:    optiontype.String,
+}
+
+var connectRoutePrefix = option.ContextVariable{
+	Arg:     "connect-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CONNECT_ROUTE_PREFIX", "connect"),
+	Envar:   "SENZING_TOOLS_CONNECT_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of the Connect protocol endpoint for the gRPC services [%s]",
+	Type:    optiontype.String,
+}
+
+var entitySearchRoutePrefix = option.ContextVariable{
+	Arg:     "entity-search-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_ENTITY_SEARCH_ROUTE_PREFIX", "entity-search"),
+	Envar:   "SENZING_TOOLS_ENTITY_SEARCH_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of Entity Search [%s]",
+	Type:    optiontype.String,
+}
+
+var goRestAPI = option.ContextVariable{
+	Arg:     "go-rest-api",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_GO_REST_API", false),
+	Envar:   "SENZING_TOOLS_GO_REST_API",
+	Help:    "Serve the Senzing REST API from Go instead of proxying to the Java POC server on port 8250. Endpoints not yet implemented in Go return an error, so Entity Search does not yet work with it [%s]",
+	Type:    optiontype.Bool,
+}
+
+var grpcTLSCertFile = option.ContextVariable{
+	Arg:     "grpc-tls-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_CERT_FILE",
+	Help:    "Path of the PEM-encoded certificate used to serve gRPC over TLS [%s]",
+	Type:    optiontype.String,
+}
+
+var grpcTLSClientCAFile = option.ContextVariable{
+	Arg:     "grpc-tls-client-ca-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_CLIENT_CA_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_CLIENT_CA_FILE",
+	Help:    "Path of the PEM-encoded CA certificate used to verify gRPC client certificates. When set, clients must present a certificate [%s]",
+	Type:    optiontype.String,
+}
+
+var grpcTLSClientCertFile = option.ContextVariable{
+	Arg:     "grpc-tls-client-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_CLIENT_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_CLIENT_CERT_FILE",
+	Help:    "Path of the PEM-encoded client certificate shown in console examples [%s]",
+	Type:    optiontype.String,
+}
+
+var grpcTLSClientKeyFile = option.ContextVariable{
+	Arg:     "grpc-tls-client-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_CLIENT_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_CLIENT_KEY_FILE",
+	Help:    "Path of the PEM-encoded client private key shown in console examples [%s]",
+	Type:    optiontype.String,
+}
+
+var grpcTLSKeyFile = option.ContextVariable{
+	Arg:     "grpc-tls-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_KEY_FILE",
+	Help:    "Path of the PEM-encoded private key of grpc-tls-cert-file [%s]",
+	Type:    optiontype.String,
+}
+
+var grpcTLSServerCAFile = option.ContextVariable{
+	Arg:     "grpc-tls-server-ca-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_GRPC_TLS_SERVER_CA_FILE", ""),
+	Envar:   "SENZING_TOOLS_GRPC_TLS_SERVER_CA_FILE",
+	Help:    "Path of the PEM-encoded CA certificate clients use to verify the gRPC server, shown in console examples [%s]",
+	Type:    optiontype.String,
+}
+
+var healthProbeIntervalInSeconds = option.ContextVariable{
+	Arg:     "health-probe-interval-in-seconds",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_HEALTH_PROBE_INTERVAL_IN_SECONDS", 10),
+	Envar:   "SENZING_TOOLS_HEALTH_PROBE_INTERVAL_IN_SECONDS",
+	Help:    "Seconds between checks of the services whose status the console shows [%s]",
+	Type:    optiontype.Int,
+}
+
+var httpRedirectPort = option.ContextVariable{
+	Arg:     "http-redirect-port",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_HTTP_REDIRECT_PORT", 0),
+	Envar:   "SENZING_TOOLS_HTTP_REDIRECT_PORT",
+	Help:    "Port on which plain HTTP requests are redirected to HTTPS. 0 disables redirection [%s]",
+	Type:    optiontype.Int,
+}
+
+var httpTLSCertFile = option.ContextVariable{
+	Arg:     "http-tls-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_TLS_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_TLS_CERT_FILE",
+	Help:    "Path of the PEM-encoded certificate used to serve HTTPS [%s]",
+	Type:    optiontype.String,
+}
+
+var httpTLSKeyFile = option.ContextVariable{
+	Arg:     "http-tls-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_TLS_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_HTTP_TLS_KEY_FILE",
+	Help:    "Path of the PEM-encoded private key of http-tls-cert-file [%s]",
+	Type:    optiontype.String,
+}
+
+var httpTLSSelfSigned = option.ContextVariable{
+	Arg:     "http-tls-self-signed",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_HTTP_TLS_SELF_SIGNED", false),
+	Envar:   "SENZING_TOOLS_HTTP_TLS_SELF_SIGNED",
+	Help:    "Serve HTTPS using a generated self-signed certificate when http-tls-cert-file is not set. For local use only [%s]",
+	Type:    optiontype.Bool,
+}
+
 var isInDevelopment = option.ContextVariable{
 	Arg:     "is-in-development",
 	Default: option.OsLookupEnvBool("SENZING_TOOLS_IS_IN_DEVELOPMENT", false),
@@ -43,12 +289,229 @@ var isInDevelopment = option.ContextVariable{
 	Type:    optiontype.Bool,
 }
 
+var jupyterLabRoutePrefix = option.ContextVariable{
+	Arg:     "jupyter-lab-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_JUPYTER_LAB_ROUTE_PREFIX", "jupyter"),
+	Envar:   "SENZING_TOOLS_JUPYTER_LAB_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of JupyterLab. Must match JupyterLab's base_url [%s]",
+	Type:    optiontype.String,
+}
+
+var observerBufferSize = option.ContextVariable{
+	Arg:     "observer-buffer-size",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_OBSERVER_BUFFER_SIZE", observing.DefaultBufferSize),
+	Envar:   "SENZING_TOOLS_OBSERVER_BUFFER_SIZE",
+	Help:    "Number of recent Senzing observer messages kept in memory and served at /observations. 0 disables [%s]",
+	Type:    optiontype.Int,
+}
+
+var observerFile = option.ContextVariable{
+	Arg:     "observer-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OBSERVER_FILE", ""),
+	Envar:   "SENZING_TOOLS_OBSERVER_FILE",
+	Help:    "Path of a file Senzing observer messages are appended to, one per line [%s]",
+	Type:    optiontype.String,
+}
+
+var observerStdout = option.ContextVariable{
+	Arg:     "observer-stdout",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_OBSERVER_STDOUT", false),
+	Envar:   "SENZING_TOOLS_OBSERVER_STDOUT",
+	Help:    "Print Senzing observer messages to stdout [%s]",
+	Type:    optiontype.Bool,
+}
+
+var playgroundHost = option.ContextVariable{
+	Arg:     "playground-host",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_PLAYGROUND_HOST", "localhost"),
+	Envar:   "SENZING_TOOLS_PLAYGROUND_HOST",
+	Help:    "Host name of the playground that commands such as healthcheck connect to. With --http-tls-cert-file, use a name the certificate is issued for [%s]",
+	Type:    optiontype.String,
+}
+
+var shutdownTimeoutInSeconds = option.ContextVariable{
+	Arg:     "shutdown-timeout-in-seconds",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_SHUTDOWN_TIMEOUT_IN_SECONDS", 10),
+	Envar:   "SENZING_TOOLS_SHUTDOWN_TIMEOUT_IN_SECONDS",
+	Help:    "Seconds to wait for in-flight requests to finish when shutting down. 0 waits indefinitely [%s]",
+	Type:    optiontype.Int,
+}
+
+var singlePort = option.ContextVariable{
+	Arg:     "single-port",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_SINGLE_PORT", false),
+	Envar:   "SENZING_TOOLS_SINGLE_PORT",
+	Help:    "Serve gRPC on the HTTP port, using the HTTP server's TLS settings, instead of on grpc-port [%s]",
+	Type:    optiontype.Bool,
+}
+
+var skipDatabaseInitialization = option.ContextVariable{
+	Arg:     "skip-database-initialization",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_SKIP_DATABASE_INITIALIZATION", false),
+	Envar:   "SENZING_TOOLS_SKIP_DATABASE_INITIALIZATION",
+	Help:    "Leave a missing or empty SQLite database file alone, instead of creating the Senzing schema and default configuration in it [%s]",
+	Type:    optiontype.Bool,
+}
+
+var snapshotDir = option.ContextVariable{
+	Arg:     "snapshot-dir",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SNAPSHOT_DIR", ""),
+	Envar:   "SENZING_TOOLS_SNAPSHOT_DIR",
+	Help:    "Directory snapshots of a SQLite repository are saved in. Default: \"snapshots\" beside the database file [%s]",
+	Type:    optiontype.String,
+}
+
+var superviseJupyterLab = option.ContextVariable{
+	Arg:     "supervise-jupyter-lab",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_SUPERVISE_JUPYTER_LAB", false),
+	Envar:   "SENZING_TOOLS_SUPERVISE_JUPYTER_LAB",
+	Help:    "Start JupyterLab on port 8888 and restart it if it exits [%s]",
+	Type:    optiontype.Bool,
+}
+
+var supervisePocServer = option.ContextVariable{
+	Arg:     "supervise-poc-server",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_SUPERVISE_POC_SERVER", false),
+	Envar:   "SENZING_TOOLS_SUPERVISE_POC_SERVER",
+	Help:    "Start the Java POC server on port 8250 and restart it if it exits. Requires Java. Ignored with go-rest-api, as nothing is proxied to it [%s]",
+	Type:    optiontype.Bool,
+}
+
+var supervisorProgramsFile = option.ContextVariable{
+	Arg:     "supervisor-programs-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SUPERVISOR_PROGRAMS_FILE", ""),
+	Envar:   "SENZING_TOOLS_SUPERVISOR_PROGRAMS_FILE",
+	Help:    "Path of a JSON file of additional programs to start and restart. Example: {\"programs\": [{\"name\": \"x\", \"command\": \"/bin/x\", \"arguments\": []}]} [%s]",
+	Type:    optiontype.String,
+}
+
+var swaggerURLRoutePrefix = option.ContextVariable{
+	Arg:     "swagger-url-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SWAGGER_URL_ROUTE_PREFIX", "swagger"),
+	Envar:   "SENZING_TOOLS_SWAGGER_URL_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of SwaggerUI [%s]",
+	Type:    optiontype.String,
+}
+
+var tlsCAFile = option.ContextVariable{
+	Arg:     "tls-ca-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TLS_CA_FILE", ""),
+	Envar:   "SENZING_TOOLS_TLS_CA_FILE",
+	Help:    "Path of the PEM-encoded CA certificate commands use to verify the playground they connect to, such as generate-certificates' ca.pem. Default: the system's CAs [%s]",
+	Type:    optiontype.String,
+}
+
+var tlsCertFile = option.ContextVariable{
+	Arg:     "tls-cert-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TLS_CERT_FILE", ""),
+	Envar:   "SENZING_TOOLS_TLS_CERT_FILE",
+	Help:    "Path of the PEM-encoded client certificate commands present to the playground they connect to, for mutual TLS [%s]",
+	Type:    optiontype.String,
+}
+
+var tlsKeyFile = option.ContextVariable{
+	Arg:     "tls-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TLS_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_TLS_KEY_FILE",
+	Help:    "Path of the PEM-encoded private key of tls-cert-file [%s]",
+	Type:    optiontype.String,
+}
+
+var tracesExporter = option.ContextVariable{
+	Arg:     "traces-exporter",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TRACES_EXPORTER", tracing.ExporterNone),
+	Envar:   "SENZING_TOOLS_TRACES_EXPORTER",
+	Help:    "Where to export OpenTelemetry traces: none, otlp, stdout, or file. otlp is configured by the OTEL_EXPORTER_OTLP_* environment variables [%s]",
+	Type:    optiontype.String,
+}
+
+var tracesFile = option.ContextVariable{
+	Arg:     "traces-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TRACES_FILE", ""),
+	Envar:   "SENZING_TOOLS_TRACES_FILE",
+	Help:    "Path of the file traces are appended to, one JSON span per line, when traces-exporter is file [%s]",
+	Type:    optiontype.String,
+}
+
+var uploadMaxCount = option.ContextVariable{
+	Arg:     "upload-max-count",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_UPLOAD_MAX_COUNT", httpserver.DefaultUploadMaxCount),
+	Envar:   "SENZING_TOOLS_UPLOAD_MAX_COUNT",
+	Help:    "Most files kept from the Load data page; the oldest not being loaded are removed to make room [%s]",
+	Type:    optiontype.Int,
+}
+
+var uploadMaxSizeInMegabytes = option.ContextVariable{
+	Arg:     "upload-max-size-in-megabytes",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_UPLOAD_MAX_SIZE_IN_MEGABYTES", httpserver.DefaultUploadMaxSize/megabyte),
+	Envar:   "SENZING_TOOLS_UPLOAD_MAX_SIZE_IN_MEGABYTES",
+	Help:    "Largest file that can be uploaded on the Load data page [%s]",
+	Type:    optiontype.Int,
+}
+
+var xtermAllowedHostnames = option.XtermAllowedHostnames.SetDefault(getDefaultAllowedHostnames())
+
+var xtermURLRoutePrefix = option.ContextVariable{
+	Arg:     "xterm-url-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_XTERM_URL_ROUTE_PREFIX", "xterm"),
+	Envar:   "SENZING_TOOLS_XTERM_URL_ROUTE_PREFIX",
+	Help:    "URL path, under base-path, of XTerm [%s]",
+	Type:    optiontype.String,
+}
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// ServerError reports which of the servers started by RunE failed.
+type ServerError struct {
+	Server string
+	Err    error
+}
+
+func (serverError *ServerError) Error() string {
+	return fmt.Sprintf("%s server failed: %v", serverError.Server, serverError.Err)
+}
+
+func (serverError *ServerError) Unwrap() error {
+	return serverError.Err
+}
+
 // ----------------------------------------------------------------------------
 // Context variables
 // ----------------------------------------------------------------------------
 
 var ContextVariablesForMultiPlatform = []option.ContextVariable{
+	apiURLRoutePrefix,
+	auditFile,
+	auditMaxBackups,
+	auditMaxSizeInMegabytes,
+	authAdmins,
+	authDefaultRole,
+	authLoaders,
+	authOidcAudience,
+	authOidcIssuer,
+	authOidcRoleClaim,
+	authOidcUsernameClaim,
+	authTokensFile,
+	authUsersFile,
+	basePath,
+	connectRoutePrefix,
+	entitySearchRoutePrefix,
+	goRestAPI,
+	grpcTLSCertFile,
+	grpcTLSClientCAFile,
+	grpcTLSClientCertFile,
+	grpcTLSClientKeyFile,
+	grpcTLSKeyFile,
+	grpcTLSServerCAFile,
+	healthProbeIntervalInSeconds,
+	httpRedirectPort,
+	httpTLSCertFile,
+	httpTLSKeyFile,
+	httpTLSSelfSigned,
 	isInDevelopment,
+	jupyterLabRoutePrefix,
 	option.AvoidServe,
 	option.Configuration,
 	option.DatabaseURL,
@@ -58,16 +521,32 @@ var ContextVariablesForMultiPlatform = []option.ContextVariable{
 	option.GrpcPort,
 	option.HTTPPort,
 	option.LogLevel,
+	observerBufferSize,
+	observerFile,
 	option.ObserverOrigin,
+	observerStdout,
 	option.ObserverURL,
 	option.ServerAddress,
+	shutdownTimeoutInSeconds,
+	singlePort,
+	skipDatabaseInitialization,
+	snapshotDir,
+	superviseJupyterLab,
+	supervisePocServer,
+	supervisorProgramsFile,
+	swaggerURLRoutePrefix,
+	tracesExporter,
+	tracesFile,
 	option.TtyOnly,
-	option.XtermAllowedHostnames.SetDefault(getDefaultAllowedHostnames()),
+	uploadMaxCount,
+	uploadMaxSizeInMegabytes,
+	xtermAllowedHostnames,
 	option.XtermArguments,
 	option.XtermCommand,
 	option.XtermConnectionErrorLimit,
 	option.XtermKeepalivePingTimeout,
 	option.XtermMaxBufferSizeBytes,
+	xtermURLRoutePrefix,
 }
 
 var ContextVariables = append(ContextVariablesForMultiPlatform, ContextVariablesForOsArch...)
@@ -105,9 +584,23 @@ func PreRun(cobraCommand *cobra.Command, args []string) {
 }
 
 // Used in construction of cobra.Command
-func RunE(_ *cobra.Command, _ []string) error {
+func RunE(cobraCommand *cobra.Command, _ []string) error {
 	var err error
-	ctx := context.Background()
+
+	// Past flag parsing, errors are runtime failures; a usage message would only bury them.
+
+	cobraCommand.SilenceUsage = true
+
+	// Cancel ctx on SIGINT or SIGTERM so the servers can drain and shut down.
+	// After the first signal, default handling is restored so a second one exits immediately.
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	shutdownTimeout := time.Duration(viper.GetInt(shutdownTimeoutInSeconds.Arg)) * time.Second
 
 	// Set default value for SENZING_TOOLS_DATABASE_URL.
 
@@ -126,40 +619,170 @@ func RunE(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	// Build observers.
+	// A SQLite database file that is missing or empty, as when running outside the container, gets the Senzing schema and default configuration.
+
+	if !viper.GetBool(skipDatabaseInitialization.Arg) {
+		err = initializeDatabaseFile(ctx, os.Stdout, senzingSettings)
+		if err != nil {
+			return err
+		}
+	}
+
+	// An in-memory SQLite database starts out empty on every run, so it always gets them.
+
+	err = initializeInMemoryDatabase(ctx, senzingSettings)
+	if err != nil {
+		return err
+	}
+
+	// Build observers of the Senzing engine: the one at the observer URL, and the built-in ones that are enabled.
+	// The same observers are registered with the gRPC and HTTP servers.
 
 	observers := []observer.Observer{}
+	if len(viper.GetString(option.ObserverURL.Arg)) > 0 {
+		urlObserver, err := observing.NewObserver(ctx, viper.GetString(option.ObserverURL.Arg), "playground")
+		if err != nil {
+			return err
+		}
+		observers = append(observers, urlObserver)
+	}
+	if viper.GetBool(observerStdout.Arg) {
+		observers = append(observers, &observer.RawObserver{ID: "playground-stdout"})
+	}
+	if len(viper.GetString(observerFile.Arg)) > 0 {
+		fileObserver, err := observing.NewFileObserver("playground-file", viper.GetString(observerFile.Arg))
+		if err != nil {
+			return err
+		}
+		defer fileObserver.Close()
+		observers = append(observers, fileObserver)
+	}
+	var bufferObserver *observing.BufferObserver
+	if viper.GetInt(observerBufferSize.Arg) > 0 {
+		bufferObserver = &observing.BufferObserver{
+			ID:   "playground-buffer",
+			Size: viper.GetInt(observerBufferSize.Arg),
+		}
+		observers = append(observers, bufferObserver)
+	}
 
-	// Setup gRPC server
+	// Setup supervisor of child processes.
 
-	grpcserver := &grpcserver.BasicGrpcServer{
+	programs, err := getSupervisedPrograms()
+	if err != nil {
+		return err
+	}
+	programSupervisor := &supervisor.BasicSupervisor{
+		Programs:        programs,
+		ShutdownTimeout: shutdownTimeout,
+	}
+
+	// Setup metrics, served by the HTTP server.
+
+	playgroundMetrics := &metrics.BasicMetrics{}
+
+	// Setup tracing.  Spans still buffered are exported once the servers have stopped.
+
+	playgroundTracing := &tracing.BasicTracing{
+		Exporter:    viper.GetString(tracesExporter.Arg),
+		File:        viper.GetString(tracesFile.Arg),
+		ServiceName: Use,
+		Version:     Version(),
+	}
+	err = playgroundTracing.Start(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = playgroundTracing.Shutdown(context.WithoutCancel(ctx))
+	}()
+
+	// Setup authentication, if any method is configured.  Otherwise every service is open.
+
+	playgroundAuthentication, err := getAuthentication()
+	if err != nil {
+		return err
+	}
+
+	// Setup the audit log, if a file is configured.
+
+	playgroundAudit, err := getAudit()
+	if err != nil {
+		return err
+	}
+	defer playgroundAudit.Close()
+
+	// Setup gRPC server.  In-process calls come from the HTTP server, which authenticates its own requests.
+
+	grpcServer := &grpcserver.BasicGrpcServer{
 		AvoidServing:          viper.GetBool(option.AvoidServe.Arg),
-		EnableAll:             true,
-		EnableSzConfig:        viper.GetBool(option.EnableSzConfig.Arg),
-		EnableSzConfigManager: viper.GetBool(option.EnableSzConfigManager.Arg),
-		EnableSzDiagnostic:    viper.GetBool(option.EnableSzDiagnostic.Arg),
-		EnableSzEngine:        viper.GetBool(option.EnableSzEngine.Arg),
-		EnableSzProduct:       viper.GetBool(option.EnableSzProduct.Arg),
+		InProcessOptions:      append(playgroundMetrics.GrpcServerOptions(metrics.ListenerInProcess), playgroundTracing.GrpcServerOptions()...),
 		LogLevelName:          viper.GetString(option.LogLevel.Arg),
 		ObserverOrigin:        viper.GetString(option.ObserverOrigin.Arg),
-		ObserverURL:           viper.GetString(option.ObserverURL.Arg),
+		Observers:             observers,
 		Port:                  viper.GetInt(option.GrpcPort.Arg),
 		SenzingSettings:       senzingSettings,
 		SenzingInstanceName:   viper.GetString(option.EngineInstanceName.Arg),
 		SenzingVerboseLogging: viper.GetInt64(option.EngineLogLevel.Arg),
+		ServerOptions:         getNetworkServerOptions(playgroundMetrics, playgroundTracing, playgroundAuthentication, playgroundAudit),
+		ShutdownTimeout:       shutdownTimeout,
+		TLSCertFile:           viper.GetString(grpcTLSCertFile.Arg),
+		TLSClientCAFile:       viper.GetString(grpcTLSClientCAFile.Arg),
+		TLSKeyFile:            viper.GetString(grpcTLSKeyFile.Arg),
+	}
+
+	// The HTTP server reaches the gRPC services in-process, whatever the gRPC TLS settings.
+
+	inProcessDialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(grpcServer.DialContext),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, playgroundTracing.GrpcDialOptions()...)
+
+	// Snapshots are offered when the repository is a SQLite database file.  Restoring one reinitializes the gRPC services
+	// and restarts the supervised programs, which have Senzing engines of their own.
+
+	playgroundSnapshots, err := getSnapshots(ctx, senzingSettings, grpcServer)
+	if errors.Is(err, snapshot.ErrUnsupportedDatabase) {
+		playgroundSnapshots, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	if playgroundSnapshots != nil {
+		playgroundSnapshots.Programs = programSupervisor
+		err = saveBaseline(ctx, os.Stdout, senzingSettings, playgroundSnapshots)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Create object and Serve.
 
 	httpServer := &httpserver.BasicHTTPServer{
-		APIUrlRoutePrefix:         "api",
+		APIUrlRoutePrefix:         viper.GetString(apiURLRoutePrefix.Arg),
+		Audit:                     playgroundAudit,
+		Authentication:            playgroundAuthentication,
 		AvoidServing:              viper.GetBool(option.AvoidServe.Arg),
+		BasePath:                  viper.GetString(basePath.Arg),
+		ConnectRoutePrefix:        viper.GetString(connectRoutePrefix.Arg),
 		EnableAll:                 true,
-		EntitySearchRoutePrefix:   "entity-search",
+		EntitySearchRoutePrefix:   viper.GetString(entitySearchRoutePrefix.Arg),
+		GoRestAPI:                 viper.GetBool(goRestAPI.Arg),
+		GrpcDialOptions:           inProcessDialOptions,
+		GrpcPort:                  viper.GetInt(option.GrpcPort.Arg),
+		GrpcTarget:                "passthrough:///in-process",
+		GrpcTLS:                   len(viper.GetString(grpcTLSCertFile.Arg)) > 0,
+		GrpcTLSCAFile:             viper.GetString(grpcTLSServerCAFile.Arg),
+		GrpcTLSClientCertFile:     viper.GetString(grpcTLSClientCertFile.Arg),
+		GrpcTLSClientKeyFile:      viper.GetString(grpcTLSClientKeyFile.Arg),
+		HealthProbeInterval:       time.Duration(viper.GetInt(healthProbeIntervalInSeconds.Arg)) * time.Second,
+		HTTPRedirectPort:          viper.GetInt(httpRedirectPort.Arg),
 		IsInDevelopment:           viper.GetBool(isInDevelopment.Arg),
-		JupyterLabRoutePrefix:     "jupyter",
+		JupyterLabRoutePrefix:     viper.GetString(jupyterLabRoutePrefix.Arg),
 		LogLevelName:              viper.GetString(option.LogLevel.Arg),
+		Metrics:                   playgroundMetrics,
 		ObserverOrigin:            viper.GetString(option.ObserverOrigin.Arg),
+		Observations:              bufferObserver,
 		Observers:                 observers,
 		OpenAPISpecificationRest:  senzingrestservice.OpenAPISpecificationJSON,
 		ReadHeaderTimeout:         60 * time.Second,
@@ -168,40 +791,66 @@ func RunE(_ *cobra.Command, _ []string) error {
 		SenzingVerboseLogging:     viper.GetInt64(option.EngineLogLevel.Arg),
 		ServerAddress:             viper.GetString(option.ServerAddress.Arg),
 		ServerPort:                viper.GetInt(option.HTTPPort.Arg),
-		SwaggerURLRoutePrefix:     "swagger",
+		ShutdownTimeout:           shutdownTimeout,
+		Snapshots:                 playgroundSnapshots,
+		Supervisor:                programSupervisor,
+		SwaggerURLRoutePrefix:     viper.GetString(swaggerURLRoutePrefix.Arg),
+		TLSCertFile:               viper.GetString(httpTLSCertFile.Arg),
+		TLSKeyFile:                viper.GetString(httpTLSKeyFile.Arg),
+		TLSSelfSigned:             viper.GetBool(httpTLSSelfSigned.Arg),
+		Tracing:                   playgroundTracing,
 		TtyOnly:                   viper.GetBool(option.TtyOnly.Arg),
-		XtermAllowedHostnames:     viper.GetStringSlice(option.XtermAllowedHostnames.Arg),
+		UploadMaxCount:            viper.GetInt(uploadMaxCount.Arg),
+		UploadMaxSize:             int64(viper.GetInt(uploadMaxSizeInMegabytes.Arg)) * megabyte,
+		Version:                   Version(),
+		XtermAllowedHostnames:     viper.GetStringSlice(xtermAllowedHostnames.Arg),
 		XtermArguments:            viper.GetStringSlice(option.XtermArguments.Arg),
 		XtermCommand:              viper.GetString(option.XtermCommand.Arg),
 		XtermConnectionErrorLimit: viper.GetInt(option.XtermConnectionErrorLimit.Arg),
 		XtermKeepalivePingTimeout: viper.GetInt(option.XtermKeepalivePingTimeout.Arg),
 		XtermMaxBufferSizeBytes:   viper.GetInt(option.XtermMaxBufferSizeBytes.Arg),
-		XtermURLRoutePrefix:       "xterm",
+		XtermURLRoutePrefix:       viper.GetString(xtermURLRoutePrefix.Arg),
 	}
 
-	// Start servers.
+	// In single-port mode, the HTTP server routes gRPC calls to the gRPC server.
 
-	var waitGroup sync.WaitGroup
-	waitGroup.Add(2)
+	if viper.GetBool(singlePort.Arg) {
+		grpcServer.AvoidListening = true
+		httpServer.GrpcHandler = grpcServer
+		httpServer.GrpcTLS = httpServer.TLSCertFile != "" || httpServer.TLSSelfSigned
+	}
 
-	go func() {
-		defer waitGroup.Done()
-		err = httpServer.Serve(ctx)
-		if err != nil {
-			fmt.Printf("Error: httpServer - %v\n", err)
-		}
-	}()
+	// Start servers and supervisor.  If one fails, cancel the others so they shut down too.
 
-	go func() {
-		defer waitGroup.Done()
-		err = grpcserver.Serve(ctx)
-		if err != nil {
-			fmt.Printf("Error: grpcServer - %v\n", err)
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		if err := httpServer.Serve(groupCtx); err != nil {
+			return &ServerError{Server: "HTTP", Err: err}
 		}
-	}()
-
-	waitGroup.Wait()
+		return nil
+	})
+	group.Go(func() error {
+		if err := grpcServer.Serve(groupCtx); err != nil {
+			return &ServerError{Server: "gRPC", Err: err}
+		}
+		return nil
+	})
+	if !viper.GetBool(option.AvoidServe.Arg) {
+		group.Go(func() error {
+			if err := programSupervisor.Serve(groupCtx); err != nil {
+				return &ServerError{Server: "Supervisor", Err: err}
+			}
+			return nil
+		})
+	}
 
+	err = group.Wait()
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		fmt.Println("Shutdown complete.")
+	}
 	return nil
 }
 
@@ -219,27 +868,337 @@ func init() {
 	cmdhelper.Init(RootCmd, ContextVariables)
 }
 
-// --- Networking -------------------------------------------------------------
+// --- Supervised programs ----------------------------------------------------
+
+// Programs the supervisor starts: the built-in ones that are enabled, then those in the programs file.
+func getSupervisedPrograms() ([]supervisor.Program, error) {
+	result := []supervisor.Program{}
+	if viper.GetBool(superviseJupyterLab.Arg) {
+		result = append(result, supervisor.Program{
+			Arguments: []string{
+				"lab",
+				"--allow-root",
+				"--no-browser",
+				"--IdentityProvider.token=",
+				"--ServerApp.allow_origin=*",
+				"--ServerApp.base_url=" + path.Join("/", viper.GetString(basePath.Arg), viper.GetString(jupyterLabRoutePrefix.Arg)),
+				"--ServerApp.port=8888",
+			},
+			Command:   "jupyter",
+			Directory: "/examples/notebooks",
+			Name:      "jupyter-lab",
+		})
+	}
+	if viper.GetBool(supervisePocServer.Arg) && !viper.GetBool(goRestAPI.Arg) {
+		result = append(result, supervisor.Program{
+			Arguments: []string{"-Dsenzing.support.dir=/opt/senzing/data", "-jar", "senzing-poc-server.jar"},
+			Command:   "java",
+			Directory: "/app",
+			Name:      "senzing-poc-server",
+		})
+	}
+	programsFile := viper.GetString(supervisorProgramsFile.Arg)
+	if len(programsFile) > 0 {
+		programs, err := supervisor.LoadPrograms(programsFile)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, programs...)
+	}
+	return result, nil
+}
 
-func getOutboundIP() net.IP {
-	conn, err := net.Dial("udp", "8.8.8.8:80")
+// --- Authentication ---------------------------------------------------------
+
+// The authentication methods and roles configured, or nil if there are no methods.
+// Users listed as both loaders and admins are admins.
+func getAuthentication() (*authentication.BasicAuthentication, error) {
+	authenticators := []authentication.Authenticator{}
+	if len(viper.GetString(authUsersFile.Arg)) > 0 {
+		usersAuthenticator, err := authentication.NewUsersAuthenticator(viper.GetString(authUsersFile.Arg))
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, usersAuthenticator)
+	}
+	if len(viper.GetString(authTokensFile.Arg)) > 0 {
+		tokensAuthenticator, err := authentication.NewTokensAuthenticator(viper.GetString(authTokensFile.Arg))
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, tokensAuthenticator)
+	}
+	if len(viper.GetString(authOidcIssuer.Arg)) > 0 {
+		authenticators = append(authenticators, &authentication.OIDCAuthenticator{
+			Audience:      viper.GetString(authOidcAudience.Arg),
+			Issuer:        viper.GetString(authOidcIssuer.Arg),
+			RoleClaim:     viper.GetString(authOidcRoleClaim.Arg),
+			UsernameClaim: viper.GetString(authOidcUsernameClaim.Arg),
+		})
+	}
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	defaultRole := viper.GetString(authDefaultRole.Arg)
+	if !authentication.IsRole(defaultRole) {
+		return nil, fmt.Errorf("%w: --%s %q", authentication.ErrUnknownRole, authDefaultRole.Arg, defaultRole)
+	}
+	roles := map[string]string{}
+	for _, name := range viper.GetStringSlice(authLoaders.Arg) {
+		roles[name] = authentication.RoleLoader
+	}
+	for _, name := range viper.GetStringSlice(authAdmins.Arg) {
+		roles[name] = authentication.RoleAdmin
+	}
+	return &authentication.BasicAuthentication{
+		Authenticators: authenticators,
+		DefaultRole:    defaultRole,
+		Realm:          Use,
+		Roles:          roles,
+	}, nil
+}
+
+// --- Audit ------------------------------------------------------------------
+
+// The audit log, opened, or nil if no file is configured.
+func getAudit() (*audit.BasicAudit, error) {
+	if len(viper.GetString(auditFile.Arg)) == 0 {
+		return nil, nil
+	}
+	result := &audit.BasicAudit{
+		File:       viper.GetString(auditFile.Arg),
+		MaxBackups: viper.GetInt(auditMaxBackups.Arg),
+		MaxSize:    int64(viper.GetInt(auditMaxSizeInMegabytes.Arg)) * megabyte,
+	}
+	err := result.Open()
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer func() {
-		if err := conn.Close(); err != nil {
-			panic(err)
+	return result, nil
+}
+
+// --- Database initialization ------------------------------------------------
+
+/*
+Create the Senzing schema, from szcore-schema-sqlite-create.sql, and install the default configuration
+in the SQLite database file senzingSettings name, if it is missing or empty.  What is done is reported to out.
+Other repositories, including in-memory ones, are left alone.
+*/
+func initializeDatabaseFile(ctx context.Context, out io.Writer, senzingSettings string) error {
+	databaseFile, err := snapshot.DatabaseFile(ctx, senzingSettings)
+	if errors.Is(err, snapshot.ErrUnsupportedDatabase) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fileInfo, err := os.Stat(databaseFile)
+	isMissing := errors.Is(err, os.ErrNotExist)
+	if err != nil && !isMissing {
+		return err
+	}
+	if !isMissing && fileInfo.Size() > 0 {
+		return nil
+	}
+	databaseInitializer, err := getDatabaseInitializer(ctx, senzingSettings)
+	if err != nil {
+		return err
+	}
+	missingDir := getMissingDir(databaseFile)
+	err = databaseInitializer.Initialize(ctx)
+	if err != nil {
+
+		// Leave no half-initialized file behind, to be mistaken for a repository on the next run,
+		// nor the directories created for it.
+
+		if isMissing {
+			_ = os.Remove(databaseFile)
 		}
-	}()
-	localAddr := conn.LocalAddr().(*net.UDPAddr)
-	return localAddr.IP
+		if len(missingDir) > 0 {
+			_ = os.RemoveAll(missingDir)
+		}
+		return fmt.Errorf("could not initialize the Senzing repository %s; use --%s to leave it alone: %w", databaseFile, skipDatabaseInitialization.Arg, err)
+	}
+	state := "empty"
+	if isMissing {
+		state = "missing"
+	}
+	_, err = fmt.Fprintf(out, "Initialized the Senzing repository %s, which was %s: created the schema from %s and installed the default configuration.\n", databaseFile, state, databaseInitializer.SQLFile)
+	return err
+}
+
+// Create the Senzing schema and install the default configuration in the repository senzingSettings name,
+// if it is a shared in-memory SQLite database.  Other repositories are left alone.
+func initializeInMemoryDatabase(ctx context.Context, senzingSettings string) error {
+	parsedSenzingSettings, err := settingsparser.New(senzingSettings)
+	if err != nil {
+		return err
+	}
+	databaseURLs, err := parsedSenzingSettings.GetDatabaseURLs(ctx)
+	if err != nil || len(databaseURLs) != 1 {
+		return err
+	}
+	parsedDatabaseURL, err := dbhelper.ParseDatabaseURL(databaseURLs[0])
+	if err != nil {
+		return err
+	}
+	queryParameters := parsedDatabaseURL.Query()
+	if parsedDatabaseURL.Scheme != "sqlite3" || queryParameters.Get("mode") != "memory" || queryParameters.Get("cache") != "shared" {
+		return nil
+	}
+	databaseInitializer, err := getDatabaseInitializer(ctx, senzingSettings)
+	if err != nil {
+		return err
+	}
+	return databaseInitializer.Initialize(ctx)
+}
+
+// The outermost of the directories of file that are missing, or "" if its directory exists.
+func getMissingDir(file string) string {
+	result := ""
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		_, err := os.Stat(dir)
+		if !errors.Is(err, os.ErrNotExist) {
+			return result
+		}
+		result = dir
+		if dir == filepath.Dir(dir) {
+			return result
+		}
+	}
+}
+
+// Senzing engine settings like senzingSettings, but whose repository is the SQLite database file databaseFile.
+func getDatabaseFileSettings(senzingSettings string, databaseFile string) (string, error) {
+	parsedSettings := map[string]any{}
+	err := json.Unmarshal([]byte(senzingSettings), &parsedSettings)
+	if err != nil {
+		return "", err
+	}
+	sqlSettings, isMap := parsedSettings["SQL"].(map[string]any)
+	if !isMap {
+		sqlSettings = map[string]any{}
+		parsedSettings["SQL"] = sqlSettings
+	}
+	absoluteFile, err := filepath.Abs(databaseFile)
+	if err != nil {
+		return "", err
+	}
+	sqlSettings["CONNECTION"] = "sqlite3://na:na@" + filepath.ToSlash(absoluteFile)
+	result, err := json.Marshal(parsedSettings)
+	return string(result), err
+}
+
+// The init-database initializer of the repository senzingSettings name, with the Senzing schema in its resource path.
+func getDatabaseInitializer(ctx context.Context, senzingSettings string) (*initializer.BasicInitializer, error) {
+	parsedSenzingSettings, err := settingsparser.New(senzingSettings)
+	if err != nil {
+		return nil, err
+	}
+	resourcePath, err := parsedSenzingSettings.GetResourcePath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &initializer.BasicInitializer{
+		ObserverOrigin:        viper.GetString(option.ObserverOrigin.Arg),
+		ObserverURL:           viper.GetString(option.ObserverURL.Arg),
+		SenzingInstanceName:   viper.GetString(option.EngineInstanceName.Arg),
+		SenzingLogLevel:       viper.GetString(option.LogLevel.Arg),
+		SenzingSettings:       senzingSettings,
+		SenzingVerboseLogging: viper.GetInt64(option.EngineLogLevel.Arg),
+		SQLFile:               filepath.Join(resourcePath, "schema", "szcore-schema-sqlite-create.sql"),
+	}
+	return result, nil
+}
+
+// --- Snapshots --------------------------------------------------------------
+
+/*
+Snapshots of the SQLite database file senzingSettings name, saved to --snapshot-dir.
+Restoring them reinitializes server, if not nil.
+Returns snapshot.ErrUnsupportedDatabase if the repository is not a SQLite database file.
+*/
+func getSnapshots(ctx context.Context, senzingSettings string, server snapshot.Server) (*snapshot.BasicSnapshots, error) {
+	databaseFile, err := snapshot.DatabaseFile(ctx, senzingSettings)
+	if err != nil {
+		return nil, err
+	}
+	directory := viper.GetString(snapshotDir.Arg)
+	if len(directory) == 0 {
+		directory = snapshot.DefaultDirectory(databaseFile)
+	}
+	result := &snapshot.BasicSnapshots{
+		DatabaseFile: databaseFile,
+		Directory:    directory,
+		Server:       server,
+	}
+	return result, nil
 }
 
+/*
+Save the baseline of snapshots, unless it has been saved: a repository the init-database initializer
+creates with the Senzing schema and the default configuration, as initializeDatabaseFile does.
+Without it, only the reset command fails, so a failure is reported to out rather than returned.
+*/
+func saveBaseline(ctx context.Context, out io.Writer, senzingSettings string, snapshots *snapshot.BasicSnapshots) error {
+	isSaved, err := snapshots.SaveBaseline(ctx, func(ctx context.Context, file string) error {
+		baselineSettings, err := getDatabaseFileSettings(senzingSettings, file)
+		if err != nil {
+			return err
+		}
+		databaseInitializer, err := getDatabaseInitializer(ctx, baselineSettings)
+		if err != nil {
+			return err
+		}
+		return databaseInitializer.Initialize(ctx)
+	})
+	switch {
+	case err != nil:
+		_, err = fmt.Fprintf(out, "Could not save the baseline the reset command restores: %v\n", err)
+	case isSaved:
+		_, err = fmt.Fprintf(out, "Saved the baseline the reset command restores to %s.\n", snapshots.Directory)
+	}
+	return err
+}
+
+// --- Networking -------------------------------------------------------------
+
+// Options of the listening gRPC server.  Calls are counted and traced even when they fail authentication.
+// Calls that change data are audited once authenticated.
+func getNetworkServerOptions(playgroundMetrics *metrics.BasicMetrics, playgroundTracing *tracing.BasicTracing, playgroundAuthentication *authentication.BasicAuthentication, playgroundAudit *audit.BasicAudit) []grpc.ServerOption {
+	result := playgroundMetrics.GrpcServerOptions(metrics.ListenerNetwork)
+	result = append(result, playgroundTracing.GrpcServerOptions()...)
+	result = append(result, playgroundAuthentication.GrpcServerOptions(grpcserver.MethodRole)...)
+	return append(result, playgroundAudit.GrpcServerOptions(grpcserver.ChangesData)...)
+}
+
+// Hostnames and IP addresses by which this machine may be reached.
+// Discovery is local only, so it works on air-gapped machines; anything not found is skipped.
 func getDefaultAllowedHostnames() []string {
 	result := []string{"localhost"}
-	outboundIPAddress := getOutboundIP().String()
-	if len(outboundIPAddress) > 0 {
-		result = append(result, outboundIPAddress)
+	found := map[string]bool{"localhost": true}
+	appendOnce := func(hostname string) {
+		if len(hostname) > 0 && !found[hostname] {
+			found[hostname] = true
+			result = append(result, hostname)
+		}
+	}
+
+	hostname, err := os.Hostname()
+	if err == nil {
+		appendOnce(hostname)
+	}
+
+	interfaceAddresses, err := net.InterfaceAddrs()
+	if err != nil {
+		return result
+	}
+	for _, interfaceAddress := range interfaceAddresses {
+		ipNet, isIPNet := interfaceAddress.(*net.IPNet)
+		if !isIPNet || ipNet.IP.IsLinkLocalUnicast() || ipNet.IP.IsUnspecified() {
+			continue
+		}
+		appendOnce(ipNet.IP.String())
 	}
 	return result
 }
@@ -0,0 +1,363 @@
+/*
+ */
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+	"github.com/senzing-garage/go-cmdhelping/settings"
+	"github.com/senzing-garage/playground/httpserver"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrSnapshotsUnavailable is returned by the snapshot commands when the running playground does not serve snapshots.
+var ErrSnapshotsUnavailable = errors.New("the running playground has no snapshots: its repository is not a SQLite database file")
+
+// ErrUnverifiedPlayground is returned by the snapshot commands rather than send --http-bearer-token to a playground whose certificate cannot be verified.
+var ErrUnverifiedPlayground = errors.New("the playground's self-signed certificate cannot be verified: set --tls-ca-file to send it --http-bearer-token")
+
+// snapshotter is satisfied by snapshot.BasicSnapshots, used when no playground is running,
+// and by remoteSnapshots, which asks the running playground.
+type snapshotter interface {
+	Create(ctx context.Context, name string) (snapshot.Snapshot, error)
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context) ([]snapshot.Snapshot, error)
+	Restore(ctx context.Context, name string) error
+}
+
+// remoteSnapshots are the snapshots of the playground on --playground-host, reached at url.
+type remoteSnapshots struct {
+	client *http.Client
+	token  string
+	url    string
+}
+
+// How long the snapshot commands wait for the playground.
+const snapshotTimeout = 10 * time.Minute
+
+var httpBearerToken = option.ContextVariable{
+	Arg:     "http-bearer-token",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_HTTP_BEARER_TOKEN", ""),
+	Envar:   "SENZING_TOOLS_HTTP_BEARER_TOKEN",
+	Help:    "Token sent to the playground, when it requires authentication.  It is only sent over HTTPS that is verified, or over HTTP [%s]",
+	Type:    optiontype.String,
+}
+
+var snapshotContextVariables = []option.ContextVariable{
+	basePath,
+	httpBearerToken,
+	httpTLSCertFile,
+	httpTLSSelfSigned,
+	option.Configuration,
+	option.DatabaseURL,
+	option.EngineSettings,
+	option.HTTPPort,
+	playgroundHost,
+	snapshotDir,
+	tlsCAFile,
+}
+
+const snapshotsHelp = `
+Snapshots are made by the playground on --playground-host, which holds its Senzing calls
+back meanwhile; use the same --http-port, --base-path, and HTTPS settings.  When it requires
+authentication, these commands need the admin role: set --http-bearer-token.  With
+--http-tls-self-signed, the token is only sent when --tls-ca-file verifies the certificate.
+When no playground is running on this machine, they work on the SQLite database file
+of --database-url or --engine-settings.
+`
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot [NAME]",
+	Short: "Save a named snapshot of the SQLite repository, or list the snapshots",
+	Long: `Save a snapshot of the Senzing repository, a SQLite database file, as NAME.
+Without NAME, list the snapshots saved.  With --delete, delete snapshot NAME.
+Restore a snapshot with "playground restore NAME".
+` + snapshotsHelp + `
+Examples:
+    playground snapshot before-exercise-2
+    playground snapshot
+    playground snapshot --delete before-exercise-2
+`,
+	Args: cobra.MaximumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, snapshotContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		isDelete, err := cmd.Flags().GetBool("delete")
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), snapshotTimeout)
+		defer cancel()
+		snapshots, err := getSnapshotter(ctx, os.Stdout)
+		if err != nil {
+			return err
+		}
+		return snapshotAction(ctx, os.Stdout, snapshots, args, isDelete)
+	},
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore NAME",
+	Short: "Restore the SQLite repository from a snapshot",
+	Long: `Replace the contents of the Senzing repository, a SQLite database file, with snapshot NAME,
+saved by "playground snapshot NAME".  Everything done since the snapshot is lost.
+A running playground reinitializes its Senzing engine with the configuration restored,
+and restarts the programs it supervises, such as JupyterLab.  Restart programs started
+in xterm sessions yourself: their Senzing engines still hold the old configuration.
+` + snapshotsHelp + `
+Examples:
+    playground restore before-exercise-2
+`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, snapshotContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		ctx, cancel := context.WithTimeout(cmd.Context(), snapshotTimeout)
+		defer cancel()
+		snapshots, err := getSnapshotter(ctx, os.Stdout)
+		if err != nil {
+			return err
+		}
+		return restoreAction(ctx, os.Stdout, snapshots, args[0])
+	},
+}
+
+// resetCmd represents the reset command
+var resetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Reset the SQLite repository to the baseline",
+	Long: `Replace the contents of the Senzing repository, a SQLite database file, with the baseline:
+the Senzing schema and the default configuration, without data sources or records.
+The baseline is created in the snapshot directory when the playground starts, if it is missing.
+Snapshots are kept.  As with "playground restore", the programs the playground supervises
+are restarted; restart programs started in xterm sessions yourself.
+` + snapshotsHelp + `
+Examples:
+    playground snapshot before-reset && playground reset
+`,
+	Args: cobra.NoArgs,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		cmdhelper.PreRun(cmd, args, Use, snapshotContextVariables)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_ = args
+		cmd.SilenceUsage = true
+		ctx, cancel := context.WithTimeout(cmd.Context(), snapshotTimeout)
+		defer cancel()
+		snapshots, err := getSnapshotter(ctx, os.Stdout)
+		if err != nil {
+			return err
+		}
+		return restoreAction(ctx, os.Stdout, snapshots, snapshot.BaselineName)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(snapshotCmd)
+	cmdhelper.Init(snapshotCmd, snapshotContextVariables)
+	snapshotCmd.Flags().Bool("delete", false, "Delete snapshot NAME")
+	RootCmd.AddCommand(restoreCmd)
+	cmdhelper.Init(restoreCmd, snapshotContextVariables)
+	RootCmd.AddCommand(resetCmd)
+	cmdhelper.Init(resetCmd, snapshotContextVariables)
+}
+
+// Save, delete, or list snapshots, as the arguments and --delete of the snapshot command say.
+func snapshotAction(ctx context.Context, out io.Writer, snapshots snapshotter, args []string, isDelete bool) error {
+	var err error
+	switch {
+	case isDelete && len(args) == 0:
+		return fmt.Errorf("%w: name the snapshot to delete", snapshot.ErrInvalidName)
+	case isDelete:
+		err = snapshots.Delete(ctx, args[0])
+		if err == nil {
+			_, err = fmt.Fprintf(out, "Deleted snapshot %s.\n", args[0])
+		}
+		return err
+	case len(args) > 0:
+		var created snapshot.Snapshot
+		created, err = snapshots.Create(ctx, args[0])
+		if err == nil {
+			_, err = fmt.Fprintf(out, "Saved snapshot %s (%s).\n", created.Name, formatSnapshotSize(created.Size))
+		}
+		return err
+	}
+	list, err := snapshots.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		_, err = fmt.Fprintln(out, "No snapshots.")
+		return err
+	}
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tSAVED\tSIZE")
+	for _, saved := range list {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", saved.Name, saved.Time.Format(time.DateTime), formatSnapshotSize(saved.Size))
+	}
+	return writer.Flush()
+}
+
+// Restore a snapshot, or the baseline for snapshot.BaselineName.
+func restoreAction(ctx context.Context, out io.Writer, snapshots snapshotter, name string) error {
+	err := snapshots.Restore(ctx, name)
+	if err != nil {
+		return err
+	}
+	if name == snapshot.BaselineName {
+		_, err = fmt.Fprintln(out, "Reset the repository to the baseline.")
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Restored the repository from snapshot %s.\n", name)
+	return err
+}
+
+/*
+The snapshots of the playground on --playground-host or, if none is running on this machine,
+those of the database file it would use.  The latter is reported to out.
+*/
+func getSnapshotter(ctx context.Context, out io.Writer) (snapshotter, error) {
+	client, verified, err := getPlaygroundClient()
+	if err != nil {
+		return nil, err
+	}
+	if !verified && len(viper.GetString(httpBearerToken.Arg)) > 0 {
+		return nil, ErrUnverifiedPlayground
+	}
+	remote := &remoteSnapshots{
+		client: client,
+		token:  viper.GetString(httpBearerToken.Arg),
+		url:    getSnapshotsURL(),
+	}
+	_, err = remote.List(ctx)
+	if !errors.Is(err, syscall.ECONNREFUSED) || !isLocalHost(viper.GetString(playgroundHost.Arg)) {
+		return remote, err
+	}
+
+	// Set default value for SENZING_TOOLS_DATABASE_URL.
+
+	_, isSet := os.LookupEnv("SENZING_TOOLS_DATABASE_URL")
+	if !isSet {
+		err = os.Setenv("SENZING_TOOLS_DATABASE_URL", SenzingToolsDatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	senzingSettings, err := settings.BuildAndVerifySettings(ctx, viper.GetViper())
+	if err != nil {
+		return nil, err
+	}
+	result, err := getSnapshots(ctx, senzingSettings, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = fmt.Fprintf(out, "No playground is running on port %d; using %s directly.\n", viper.GetInt(option.HTTPPort.Arg), result.DatabaseFile)
+	if err != nil {
+		return nil, err
+	}
+	return result, saveBaseline(ctx, out, senzingSettings, result)
+}
+
+// URL of the snapshots endpoint of the playground on --playground-host.
+func getSnapshotsURL() string {
+	statusURL := getHealthcheckURL()
+	return strings.TrimSuffix(statusURL, "status") + "snapshots/"
+}
+
+// Whether host names this machine, so the database file of a playground that is not running is here.
+func isLocalHost(host string) bool {
+	if len(host) == 0 || host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Describe a size in bytes, in megabytes.
+func formatSnapshotSize(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/megabyte)
+}
+
+// --- remoteSnapshots --------------------------------------------------------
+
+func (remote *remoteSnapshots) Create(ctx context.Context, name string) (snapshot.Snapshot, error) {
+	result := snapshot.Snapshot{}
+	err := remote.do(ctx, http.MethodPost, "", httpserver.SnapshotRequest{Name: name}, &result)
+	return result, err
+}
+
+func (remote *remoteSnapshots) Delete(ctx context.Context, name string) error {
+	return remote.do(ctx, http.MethodDelete, url.PathEscape(name), nil, nil)
+}
+
+func (remote *remoteSnapshots) List(ctx context.Context) ([]snapshot.Snapshot, error) {
+	result := httpserver.SnapshotsResponse{}
+	err := remote.do(ctx, http.MethodGet, "", nil, &result)
+	return result.Snapshots, err
+}
+
+func (remote *remoteSnapshots) Restore(ctx context.Context, name string) error {
+	return remote.do(ctx, http.MethodPost, url.PathEscape(name)+"/restore", nil, nil)
+}
+
+// Send a request, with body as JSON, to the snapshots endpoint; decode the response into result.
+func (remote *remoteSnapshots) do(ctx context.Context, method string, relativePath string, body any, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(encoded)
+	}
+	requestURL := remote.url + relativePath
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if len(remote.token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+remote.token)
+	}
+	response, err := remote.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	switch {
+	case response.StatusCode == http.StatusNotFound && method == http.MethodGet:
+		return ErrSnapshotsUnavailable
+	case response.StatusCode >= http.StatusBadRequest:
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("%s %s: %s: %s", method, requestURL, response.Status, strings.TrimSpace(string(message)))
+	case result != nil:
+		return json.NewDecoder(response.Body).Decode(result)
+	}
+	return nil
+}
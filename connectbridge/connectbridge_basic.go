@@ -0,0 +1,242 @@
+package connectbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// BasicConnectBridge is the default implementation of the ConnectBridge interface.
+type BasicConnectBridge struct {
+	connection      *grpc.ClientConn
+	GrpcDialOptions []grpc.DialOption
+	GrpcTarget      string
+	mutex           sync.Mutex
+}
+
+// codec encodes messages for one Connect content type.
+type codec struct {
+	marshal   func(message proto.Message) ([]byte, error)
+	unmarshal func(data []byte, message proto.Message) error
+}
+
+// connectError is the body of a Connect error response.
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Codecs by content type.  JSON field names are the lowerCamelCase names protojson uses.
+var codecs = map[string]codec{
+	"application/json": {
+		marshal:   protojson.Marshal,
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal,
+	},
+	"application/proto": {
+		marshal:   proto.Marshal,
+		unmarshal: proto.Unmarshal,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Close method closes the connection to the gRPC server, if one was opened.
+
+Output
+  - The error from closing the connection, if any.
+*/
+func (bridge *BasicConnectBridge) Close() error {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	if bridge.connection == nil {
+		return nil
+	}
+	err := bridge.connection.Close()
+	bridge.connection = nil
+	return err
+}
+
+/*
+The ServeHTTP method serves a Connect unary call by invoking the gRPC method of
+the same name on GrpcTarget.  The request path is "/<package>.<Service>/<Method>"
+and the body is the request message, encoded as "application/json" or "application/proto".
+The method's descriptor must be linked into the program, as it is for every
+package generated by protoc-gen-go.
+
+Input
+  - w: The HTTP response writer.
+  - r: A POST request carrying a Connect unary call.
+*/
+func (bridge *BasicConnectBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	aCodec, isSupported := codecs[contentType]
+	if err != nil || !isSupported {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+	response, err := bridge.invoke(r, aCodec)
+	if err != nil {
+		writeError(w, status.Convert(err))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Get the connection to GrpcTarget, creating it on first use.
+func (bridge *BasicConnectBridge) getConnection() (*grpc.ClientConn, error) {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	if bridge.connection == nil {
+		connection, err := grpc.NewClient(bridge.GrpcTarget, bridge.GrpcDialOptions...)
+		if err != nil {
+			return nil, err
+		}
+		bridge.connection = connection
+	}
+	return bridge.connection, nil
+}
+
+// Decode the request, invoke the gRPC method, and encode its response.
+// Errors are gRPC status errors.
+func (bridge *BasicConnectBridge) invoke(r *http.Request, aCodec codec) ([]byte, error) {
+	contentEncoding := r.Header.Get("Content-Encoding")
+	if len(contentEncoding) > 0 && contentEncoding != "identity" {
+		return nil, status.Errorf(codes.Unimplemented, "content encoding %q is not supported", contentEncoding)
+	}
+	methodDescriptor, err := findMethod(r.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the request message.
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, MaxRequestBytes))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, status.Errorf(codes.ResourceExhausted, "request exceeds %d bytes", MaxRequestBytes)
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	request := dynamicpb.NewMessage(methodDescriptor.Input())
+	err = aCodec.unmarshal(body, request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Honor the client's deadline.
+
+	ctx := r.Context()
+	if timeoutHeader := r.Header.Get("Connect-Timeout-Ms"); len(timeoutHeader) > 0 {
+		timeout, err := strconv.ParseInt(timeoutHeader, 10, 64)
+		if err != nil || timeout < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid Connect-Timeout-Ms %q", timeoutHeader)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	// Invoke the gRPC method.
+
+	connection, err := bridge.getConnection()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	response := dynamicpb.NewMessage(methodDescriptor.Output())
+	err = connection.Invoke(ctx, r.URL.Path, request, response)
+	if err != nil {
+		return nil, err
+	}
+	result, err := aCodec.marshal(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Find the descriptor of a unary method from a path like "/szengine.SzEngine/GetEntityByEntityId".
+func findMethod(methodPath string) (protoreflect.MethodDescriptor, error) {
+	serviceName, methodName, isFound := strings.Cut(strings.TrimPrefix(methodPath, "/"), "/")
+	if !isFound {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", methodPath)
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service %s", serviceName)
+	}
+	serviceDescriptor, isService := descriptor.(protoreflect.ServiceDescriptor)
+	if !isService {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service %s", serviceName)
+	}
+	methodDescriptor := serviceDescriptor.Methods().ByName(protoreflect.Name(methodName))
+	if methodDescriptor == nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", methodPath)
+	}
+	if methodDescriptor.IsStreamingClient() || methodDescriptor.IsStreamingServer() {
+		return nil, status.Errorf(codes.Unimplemented, "streaming method %s is not supported", methodPath)
+	}
+	return methodDescriptor, nil
+}
+
+// Write a gRPC status as a Connect error.
+func writeError(w http.ResponseWriter, aStatus *status.Status) {
+	connectCode, isKnown := connectCodes[aStatus.Code()]
+	if !isKnown {
+		connectCode = connectCodes[codes.Unknown]
+	}
+	body, err := json.Marshal(connectError{
+		Code:    connectCode.name,
+		Message: aStatus.Message(),
+	})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(connectCode.httpStatus)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,126 @@
+package connectbridge
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicConnectBridge_Close(test *testing.T) {
+	bridge := getTestObject(test)
+	require.NoError(test, bridge.Close())
+	require.NoError(test, bridge.Close())
+}
+
+func TestBasicConnectBridge_ServeHTTP_json(test *testing.T) {
+	bridge := getTestObject(test)
+	response := serveTestRequest(bridge, http.MethodPost, "/grpc.health.v1.Health/Check", "application/json", `{"service": ""}`)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Equal(test, "application/json", response.Header().Get("Content-Type"))
+	assert.JSONEq(test, `{"status": "SERVING"}`, response.Body.String())
+}
+
+func TestBasicConnectBridge_ServeHTTP_proto(test *testing.T) {
+	bridge := getTestObject(test)
+	request, err := proto.Marshal(&grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err)
+	response := serveTestRequest(bridge, http.MethodPost, "/grpc.health.v1.Health/Check", "application/proto", string(request))
+	assert.Equal(test, http.StatusOK, response.Code)
+	healthCheckResponse := &grpc_health_v1.HealthCheckResponse{}
+	require.NoError(test, proto.Unmarshal(response.Body.Bytes(), healthCheckResponse))
+	assert.Equal(test, grpc_health_v1.HealthCheckResponse_SERVING, healthCheckResponse.GetStatus())
+}
+
+func TestBasicConnectBridge_ServeHTTP_errors(test *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "grpc error", method: http.MethodPost, path: "/grpc.health.v1.Health/Check", contentType: "application/json", body: `{"service": "missing"}`, expectedStatus: http.StatusNotFound, expectedCode: "not_found"},
+		{name: "invalid json", method: http.MethodPost, path: "/grpc.health.v1.Health/Check", contentType: "application/json", body: `{`, expectedStatus: http.StatusBadRequest, expectedCode: "invalid_argument"},
+		{name: "streaming method", method: http.MethodPost, path: "/grpc.health.v1.Health/Watch", contentType: "application/json", body: `{}`, expectedStatus: http.StatusNotImplemented, expectedCode: "unimplemented"},
+		{name: "unknown method", method: http.MethodPost, path: "/grpc.health.v1.Health/Missing", contentType: "application/json", body: `{}`, expectedStatus: http.StatusNotImplemented, expectedCode: "unimplemented"},
+		{name: "unknown service", method: http.MethodPost, path: "/missing.Missing/Check", contentType: "application/json", body: `{}`, expectedStatus: http.StatusNotImplemented, expectedCode: "unimplemented"},
+		{name: "wrong method", method: http.MethodGet, path: "/grpc.health.v1.Health/Check", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "wrong content type", method: http.MethodPost, path: "/grpc.health.v1.Health/Check", contentType: "text/plain", body: `{}`, expectedStatus: http.StatusUnsupportedMediaType},
+	}
+	bridge := getTestObject(test)
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			response := serveTestRequest(bridge, testCase.method, testCase.path, testCase.contentType, testCase.body)
+			assert.Equal(test, testCase.expectedStatus, response.Code)
+			if len(testCase.expectedCode) > 0 {
+				assert.Contains(test, response.Body.String(), `"code":"`+testCase.expectedCode+`"`)
+			}
+		})
+	}
+}
+
+func TestBasicConnectBridge_ServeHTTP_timeout(test *testing.T) {
+	bridge := getTestObject(test)
+	request := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", strings.NewReader(`{}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Connect-Timeout-Ms", "soon")
+	response := httptest.NewRecorder()
+	bridge.ServeHTTP(response, request)
+	assert.Equal(test, http.StatusBadRequest, response.Code)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Serve a health service in-memory and return a bridge to it.
+func getTestObject(test *testing.T) *BasicConnectBridge {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	bridge := &BasicConnectBridge{
+		GrpcDialOptions: []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		},
+		GrpcTarget: "passthrough:///in-process",
+	}
+	test.Cleanup(func() {
+		_ = bridge.Close()
+		grpcServer.Stop()
+	})
+	return bridge
+}
+
+func serveTestRequest(bridge *BasicConnectBridge, method string, path string, contentType string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if len(contentType) > 0 {
+		request.Header.Set("Content-Type", contentType)
+	}
+	response := httptest.NewRecorder()
+	bridge.ServeHTTP(response, request)
+	return response
+}
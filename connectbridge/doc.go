@@ -0,0 +1,5 @@
+/*
+Package connectbridge serves unary gRPC methods over the Connect protocol,
+so browser pages can call them with fetch() and JSON.
+*/
+package connectbridge
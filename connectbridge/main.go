@@ -0,0 +1,51 @@
+package connectbridge
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The ConnectBridge interface...
+type ConnectBridge interface {
+	Close() error
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Largest request body accepted.  Matches the gRPC default maximum message size.
+const MaxRequestBytes = 4 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Connect error codes, and the HTTP status each is sent with, by gRPC status code.
+var connectCodes = map[codes.Code]struct {
+	httpStatus int
+	name       string
+}{
+	codes.Canceled:           {httpStatus: 499, name: "canceled"},
+	codes.Unknown:            {httpStatus: http.StatusInternalServerError, name: "unknown"},
+	codes.InvalidArgument:    {httpStatus: http.StatusBadRequest, name: "invalid_argument"},
+	codes.DeadlineExceeded:   {httpStatus: http.StatusGatewayTimeout, name: "deadline_exceeded"},
+	codes.NotFound:           {httpStatus: http.StatusNotFound, name: "not_found"},
+	codes.AlreadyExists:      {httpStatus: http.StatusConflict, name: "already_exists"},
+	codes.PermissionDenied:   {httpStatus: http.StatusForbidden, name: "permission_denied"},
+	codes.ResourceExhausted:  {httpStatus: http.StatusTooManyRequests, name: "resource_exhausted"},
+	codes.FailedPrecondition: {httpStatus: http.StatusBadRequest, name: "failed_precondition"},
+	codes.Aborted:            {httpStatus: http.StatusConflict, name: "aborted"},
+	codes.OutOfRange:         {httpStatus: http.StatusBadRequest, name: "out_of_range"},
+	codes.Unimplemented:      {httpStatus: http.StatusNotImplemented, name: "unimplemented"},
+	codes.Internal:           {httpStatus: http.StatusInternalServerError, name: "internal"},
+	codes.Unavailable:        {httpStatus: http.StatusServiceUnavailable, name: "unavailable"},
+	codes.DataLoss:           {httpStatus: http.StatusInternalServerError, name: "data_loss"},
+	codes.Unauthenticated:    {httpStatus: http.StatusUnauthorized, name: "unauthenticated"},
+}
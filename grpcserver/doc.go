@@ -0,0 +1,4 @@
+/*
+Package grpcserver serves the Senzing SDK over gRPC.
+*/
+package grpcserver
@@ -0,0 +1,699 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/serve-grpc/szconfigmanagerserver"
+	"github.com/senzing-garage/serve-grpc/szconfigserver"
+	"github.com/senzing-garage/serve-grpc/szdiagnosticserver"
+	"github.com/senzing-garage/serve-grpc/szengineserver"
+	"github.com/senzing-garage/serve-grpc/szproductserver"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-proto/go/szconfig"
+	"github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+	"github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"
+	"github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// BasicGrpcServer is the default implementation of the GrpcServer interface.
+type BasicGrpcServer struct {
+	AvoidListening        bool
+	AvoidServing          bool
+	exclusive             sync.RWMutex
+	httpCalls             httpCalls
+	initialized           []destroyer
+	inProcessListener     *bufconn.Listener
+	inProcessOnce         sync.Once
+	InProcessOptions      []grpc.ServerOption
+	logger                logging.Logging
+	LogLevelName          string
+	ObserverOrigin        string
+	Observers             []observer.Observer
+	Port                  int
+	SenzingSettings       string
+	SenzingInstanceName   string
+	SenzingVerboseLogging int64
+	ServerOptions         []grpc.ServerOption
+	ShutdownTimeout       time.Duration
+	TLSCertFile           string
+	TLSClientCAFile       string
+	TLSKeyFile            string
+}
+
+// httpCalls tracks the calls served through ServeHTTP.
+// grpc.Server.GracefulStop cannot drain them, so Serve does.
+type httpCalls struct {
+	count   int
+	drained chan struct{}
+	mutex   sync.Mutex
+	server  *grpc.Server
+}
+
+// serviceRegistrars registers each service with every one of its gRPC servers.
+type serviceRegistrars []grpc.ServiceRegistrar
+
+// destroyer is satisfied by the Senzing SDK objects this server initializes.
+type destroyer interface {
+	Destroy(ctx context.Context) error
+}
+
+// defaultConfigGetter is satisfied by the Senzing SDK object that reads the default configuration.
+type defaultConfigGetter interface {
+	GetDefaultConfigID(ctx context.Context) (int64, error)
+}
+
+// reinitializer is satisfied by the Senzing SDK objects that load a configuration.
+type reinitializer interface {
+	Reinitialize(ctx context.Context, configID int64) error
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Serve method starts the gRPC server and blocks until it stops.
+It serves the SzConfig, SzConfigManager, SzDiagnostic, SzEngine, and SzProduct services
+with the repository in SenzingSettings, which must already hold the Senzing schema.
+With TLSCertFile and TLSKeyFile the server speaks TLS, and with TLSClientCAFile
+it also requires clients to present a certificate signed by that CA.
+With AvoidListening, no port is opened; calls arrive through ServeHTTP instead.
+Either way, the same services are also served in-process to clients using DialContext.
+ServerOptions, such as interceptors, apply to the listening server; InProcessOptions to the in-process one.
+Both servers hold calls back while WithoutCalls runs.
+When ctx is cancelled, the server stops accepting new calls, waits up to
+ShutdownTimeout for in-flight calls to finish, and destroys the Senzing SDK
+objects it initialized.  A ShutdownTimeout of zero waits indefinitely.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - nil after a clean shutdown, ErrShutdownTimeout if in-flight calls had to
+    be abandoned, otherwise the error that stopped the server.
+*/
+func (grpcServer *BasicGrpcServer) Serve(ctx context.Context) error {
+
+	// Log entry parameters.
+
+	grpcServer.log(2000, grpcServer)
+
+	// Create server.  With TLSCertFile, serve TLS; with TLSClientCAFile, also require client certificates.
+
+	serverOptions := append([]grpc.ServerOption{}, grpcServer.ServerOptions...)
+	serverOptions = append(serverOptions, grpcServer.getExclusiveOptions()...)
+	if len(grpcServer.TLSCertFile) > 0 || len(grpcServer.TLSClientCAFile) > 0 {
+		tlsConfig, err := grpcServer.getTLSConfig()
+		if err != nil {
+			return err
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	aGrpcServer := grpc.NewServer(serverOptions...)
+
+	// In-process clients share the process, so they need no TLS.
+
+	inProcessOptions := append([]grpc.ServerOption{}, grpcServer.InProcessOptions...)
+	inProcessServer := grpc.NewServer(append(inProcessOptions, grpcServer.getExclusiveOptions()...)...)
+	registrars := serviceRegistrars{aGrpcServer, inProcessServer}
+
+	// Register services with gRPC servers.  WithoutCalls waits until they are.
+	// Once services are initialized, release the Senzing SDK objects on the way out.
+
+	defer grpcServer.destroy(context.WithoutCancel(ctx))
+	err := grpcServer.enableServices(ctx, registrars)
+	if err != nil {
+		return err
+	}
+
+	// Enable the standard health service, so clients and probes can tell whether the server is serving.
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(registrars, healthServer)
+
+	// Enable reflection.
+
+	reflection.Register(aGrpcServer)
+
+	// Without a listener, serve the calls an HTTP server routes to ServeHTTP.
+
+	if grpcServer.AvoidListening {
+		if grpcServer.AvoidServing {
+			grpcServer.log(2004)
+			return nil
+		}
+		grpcServer.log(2007)
+		grpcServer.httpCalls.start(aGrpcServer)
+		go grpcServer.serveInProcess(inProcessServer)
+		<-ctx.Done()
+		healthServer.Shutdown()
+		err = grpcServer.gracefulStop(aGrpcServer, inProcessServer)
+		grpcServer.log(2006)
+		return err
+	}
+
+	// Determine which port to listen on.
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcServer.Port))
+	if err != nil {
+		grpcServer.log(4001, grpcServer.Port, err)
+		return err
+	}
+
+	// Run server.
+
+	if grpcServer.AvoidServing {
+		grpcServer.log(2004)
+		return listener.Close()
+	}
+
+	grpcServer.log(2003, listener.Addr())
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- aGrpcServer.Serve(listener)
+	}()
+	go grpcServer.serveInProcess(inProcessServer)
+
+	select {
+	case err = <-serveErrors:
+		inProcessServer.Stop()
+		return err
+	case <-ctx.Done():
+	}
+
+	healthServer.Shutdown()
+	err = grpcServer.gracefulStop(aGrpcServer, inProcessServer)
+	<-serveErrors
+	grpcServer.log(2006)
+	return err
+}
+
+/*
+The DialContext method connects to the services Serve provides in-process.
+It is meant for grpc.WithContextDialer; connections wait until Serve is ready.
+
+Input
+  - ctx: A context to control the dial.
+  - target: Ignored.  Every connection reaches this server.
+
+Output
+  - A connection to the in-process gRPC server.
+*/
+func (grpcServer *BasicGrpcServer) DialContext(ctx context.Context, target string) (net.Conn, error) {
+	_ = target
+	return grpcServer.getInProcessListener().DialContext(ctx)
+}
+
+/*
+The ServeHTTP method serves a gRPC call routed to it by an HTTP server.
+It is used when AvoidListening is set, and requires HTTP/2 (TLS or h2c).
+Until Serve is ready, and once it is shutting down, calls fail as "unavailable".
+
+Input
+  - w: The HTTP response writer.
+  - r: An HTTP/2 request carrying a gRPC call.
+*/
+func (grpcServer *BasicGrpcServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	aGrpcServer := grpcServer.httpCalls.acquire()
+	if aGrpcServer == nil {
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Grpc-Status", strconv.Itoa(int(codes.Unavailable)))
+		w.Header().Set("Grpc-Message", "gRPC server is not serving")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	defer grpcServer.httpCalls.release()
+	aGrpcServer.ServeHTTP(w, r)
+}
+
+/*
+The Reinitialize method reloads the Senzing engine and diagnostic objects this server initialized
+with the default configuration now in the repository, as needed once the database is replaced.
+Call it from the function passed to WithoutCalls, so that no call sees a half-loaded engine.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (grpcServer *BasicGrpcServer) Reinitialize(ctx context.Context) error {
+	var configManager defaultConfigGetter
+	reinitializers := []reinitializer{}
+	for _, sdk := range grpcServer.initialized {
+		if aConfigManager, isConfigManager := sdk.(defaultConfigGetter); isConfigManager {
+			configManager = aConfigManager
+		}
+		if aReinitializer, isReinitializer := sdk.(reinitializer); isReinitializer {
+			reinitializers = append(reinitializers, aReinitializer)
+		}
+	}
+	if len(reinitializers) == 0 {
+		return nil
+	}
+	if configManager == nil {
+		return ErrNoConfigManager
+	}
+	configID, err := configManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return err
+	}
+	for _, aReinitializer := range reinitializers {
+		err = aReinitializer.Reinitialize(ctx, configID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+The WithoutCalls method runs fn while no gRPC call is in progress: it waits for in-flight
+calls to finish, then holds new calls back until fn returns.  It suits changes made
+underneath the Senzing engine, such as replacing its database.  Before Serve has
+initialized the services, it waits for them too.
+
+Input
+  - ctx: A context to control the wait for in-flight calls.  It is passed to fn.
+  - fn: The function to run.
+
+Output
+  - ctx.Err() if ctx is done before in-flight calls finish, otherwise the error fn returns.
+*/
+func (grpcServer *BasicGrpcServer) WithoutCalls(ctx context.Context, fn func(ctx context.Context) error) error {
+	locked := make(chan struct{})
+	go func() {
+		grpcServer.exclusive.Lock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-ctx.Done():
+		go func() {
+			<-locked
+			grpcServer.exclusive.Unlock()
+		}()
+		return ctx.Err()
+	}
+	defer grpcServer.exclusive.Unlock()
+	return fn(ctx)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+// The ChangesData function reports whether a method changes data or configuration: whether MethodRole requires more than RoleViewer.
+func ChangesData(fullMethod string) bool {
+	role := MethodRole(fullMethod)
+	return role == authentication.RoleLoader || role == authentication.RoleAdmin
+}
+
+/*
+The MethodRole function returns the role required to call a method of the services
+BasicGrpcServer serves.  Reading needs RoleViewer; loading and deleting records,
+changing the configuration, and reinitializing, RoleLoader; purging, RoleAdmin.
+The standard health service needs no credentials.
+
+Input
+  - fullMethod: Full name of the method.  Example: "/szengine.SzEngine/AddRecord".
+
+Output
+  - One of the authentication.Role constants.
+*/
+func MethodRole(fullMethod string) string {
+	if role, isListed := methodRoles[fullMethod]; isListed {
+		return role
+	}
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if role, isListed := serviceRoles[service]; isListed {
+		return role
+	}
+	return authentication.RoleAdmin
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// --- Logging ----------------------------------------------------------------
+
+// Get the Logger singleton.
+func (grpcServer *BasicGrpcServer) getLogger() logging.Logging {
+	var err error
+	if grpcServer.logger == nil {
+		options := []interface{}{
+			logging.OptionCallerSkip{Value: 3},
+			logging.OptionMessageFields{Value: []string{"id", "text", "reason", "errors", "details"}},
+		}
+		grpcServer.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return grpcServer.logger
+}
+
+// Log message.
+func (grpcServer *BasicGrpcServer) log(messageNumber int, details ...interface{}) {
+	grpcServer.getLogger().Log(messageNumber, details...)
+}
+
+// --- Calls served through ServeHTTP ----------------------------------------
+
+// Begin routing calls to server.
+func (calls *httpCalls) start(server *grpc.Server) {
+	calls.mutex.Lock()
+	defer calls.mutex.Unlock()
+	calls.server = server
+}
+
+// Count a call in, returning the server to handle it or nil if not serving.
+func (calls *httpCalls) acquire() *grpc.Server {
+	calls.mutex.Lock()
+	defer calls.mutex.Unlock()
+	if calls.server != nil {
+		calls.count++
+	}
+	return calls.server
+}
+
+// Count a call out.
+func (calls *httpCalls) release() {
+	calls.mutex.Lock()
+	defer calls.mutex.Unlock()
+	calls.count--
+	if calls.count == 0 && calls.drained != nil {
+		close(calls.drained)
+		calls.drained = nil
+	}
+}
+
+// Refuse new calls.  The returned channel is closed once in-flight calls finish.
+func (calls *httpCalls) stop() <-chan struct{} {
+	calls.mutex.Lock()
+	defer calls.mutex.Unlock()
+	calls.server = nil
+	result := make(chan struct{})
+	if calls.count == 0 {
+		close(result)
+	} else {
+		calls.drained = result
+	}
+	return result
+}
+
+// --- In-process calls -----------------------------------------------------
+
+// Get the in-memory listener in-process clients dial.
+func (grpcServer *BasicGrpcServer) getInProcessListener() *bufconn.Listener {
+	grpcServer.inProcessOnce.Do(func() {
+		grpcServer.inProcessListener = bufconn.Listen(inProcessBufferSize)
+	})
+	return grpcServer.inProcessListener
+}
+
+// Serve in-process clients until inProcessServer stops.
+func (grpcServer *BasicGrpcServer) serveInProcess(inProcessServer *grpc.Server) {
+	err := inProcessServer.Serve(grpcServer.getInProcessListener())
+	if err != nil {
+		grpcServer.log(4006, err)
+	}
+}
+
+// Register a service with every gRPC server.
+func (registrars serviceRegistrars) RegisterService(serviceDesc *grpc.ServiceDesc, implementation any) {
+	for _, registrar := range registrars {
+		registrar.RegisterService(serviceDesc, implementation)
+	}
+}
+
+// --- Calls held back by WithoutCalls ---------------------------------------
+
+// Interceptors that keep each call from running alongside WithoutCalls.  Listed last, they run after authentication.
+func (grpcServer *BasicGrpcServer) getExclusiveOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			_ = info
+			grpcServer.exclusive.RLock()
+			defer grpcServer.exclusive.RUnlock()
+			return handler(ctx, request)
+		}),
+		grpc.ChainStreamInterceptor(func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			_ = info
+			grpcServer.exclusive.RLock()
+			defer grpcServer.exclusive.RUnlock()
+			return handler(server, stream)
+		}),
+	}
+}
+
+// --- TLS ------------------------------------------------------------------
+
+func (grpcServer *BasicGrpcServer) getTLSConfig() (*tls.Config, error) {
+	if len(grpcServer.TLSCertFile) == 0 {
+		return nil, ErrMissingTLSCertFile
+	}
+	certificate, err := tls.LoadX509KeyPair(grpcServer.TLSCertFile, grpcServer.TLSKeyFile)
+	if err != nil {
+		grpcServer.log(4004, grpcServer.TLSCertFile, grpcServer.TLSKeyFile, err)
+		return nil, err
+	}
+	result := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(grpcServer.TLSClientCAFile) > 0 {
+		clientCAPEM, err := os.ReadFile(grpcServer.TLSClientCAFile)
+		if err != nil {
+			grpcServer.log(4005, grpcServer.TLSClientCAFile, err)
+			return nil, err
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCAPEM) {
+			grpcServer.log(4005, grpcServer.TLSClientCAFile, ErrNoCertificates)
+			return nil, ErrNoCertificates
+		}
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+		result.ClientCAs = clientCAs
+	}
+	return result, err
+}
+
+// --- Lifecycle --------------------------------------------------------------
+
+// Stop accepting calls and wait for in-flight calls, up to ShutdownTimeout.
+func (grpcServer *BasicGrpcServer) gracefulStop(aGrpcServer *grpc.Server, inProcessServer *grpc.Server) error {
+	grpcServer.log(2005, grpcServer.ShutdownTimeout.String())
+	stopped := make(chan struct{})
+	go func() {
+		if grpcServer.AvoidListening {
+			<-grpcServer.httpCalls.stop()
+			aGrpcServer.Stop()
+		} else {
+			aGrpcServer.GracefulStop()
+		}
+		inProcessServer.GracefulStop()
+		close(stopped)
+	}()
+
+	if grpcServer.ShutdownTimeout <= 0 {
+		<-stopped
+		return nil
+	}
+
+	timer := time.NewTimer(grpcServer.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		return nil
+	case <-timer.C:
+		grpcServer.log(3001, grpcServer.ShutdownTimeout.String())
+		aGrpcServer.Stop()
+		inProcessServer.Stop()
+		<-stopped
+		return ErrShutdownTimeout
+	}
+}
+
+// Destroy the Senzing SDK objects initialized by enableServices, in reverse order.
+func (grpcServer *BasicGrpcServer) destroy(ctx context.Context) {
+	grpcServer.exclusive.Lock()
+	defer grpcServer.exclusive.Unlock()
+	for index := len(grpcServer.initialized) - 1; index >= 0; index-- {
+		sdk := grpcServer.initialized[index]
+		if err := sdk.Destroy(ctx); err != nil {
+			grpcServer.log(4003, fmt.Sprintf("%T", sdk), err)
+		}
+	}
+	grpcServer.initialized = nil
+}
+
+// --- Enable services --------------------------------------------------------
+
+// Initialize and register every Senzing service, holding WithoutCalls back meanwhile.
+func (grpcServer *BasicGrpcServer) enableServices(ctx context.Context, registrars serviceRegistrars) error {
+	grpcServer.exclusive.Lock()
+	defer grpcServer.exclusive.Unlock()
+	enablers := []func(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error{
+		grpcServer.enableSzConfig,
+		grpcServer.enableSzConfigManager,
+		grpcServer.enableSzDiagnostic,
+		grpcServer.enableSzEngine,
+		grpcServer.enableSzProduct,
+	}
+	for _, enable := range enablers {
+		err := enable(ctx, registrars)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (grpcServer *BasicGrpcServer) enableSzConfig(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error {
+	server := &szconfigserver.SzConfigServer{}
+	err := server.SetLogLevel(ctx, grpcServer.LogLevelName)
+	if err != nil {
+		return err
+	}
+	sdk := szconfigserver.GetSdkSzConfig()
+	err = sdk.Initialize(ctx, grpcServer.SenzingInstanceName, grpcServer.SenzingSettings, grpcServer.SenzingVerboseLogging)
+	if err != nil {
+		return err
+	}
+	grpcServer.initialized = append(grpcServer.initialized, sdk)
+	for _, observer := range grpcServer.Observers {
+		err = server.RegisterObserver(ctx, observer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(grpcServer.ObserverOrigin) > 0 {
+		server.SetObserverOrigin(ctx, grpcServer.ObserverOrigin)
+	}
+	szconfig.RegisterSzConfigServer(serviceRegistrar, server)
+	return nil
+}
+
+func (grpcServer *BasicGrpcServer) enableSzConfigManager(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error {
+	server := &szconfigmanagerserver.SzConfigManagerServer{}
+	err := server.SetLogLevel(ctx, grpcServer.LogLevelName)
+	if err != nil {
+		return err
+	}
+	sdk := szconfigmanagerserver.GetSdkSzConfigManager()
+	err = sdk.Initialize(ctx, grpcServer.SenzingInstanceName, grpcServer.SenzingSettings, grpcServer.SenzingVerboseLogging)
+	if err != nil {
+		return err
+	}
+	grpcServer.initialized = append(grpcServer.initialized, sdk)
+	for _, observer := range grpcServer.Observers {
+		err = server.RegisterObserver(ctx, observer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(grpcServer.ObserverOrigin) > 0 {
+		server.SetObserverOrigin(ctx, grpcServer.ObserverOrigin)
+	}
+	szconfigmanager.RegisterSzConfigManagerServer(serviceRegistrar, server)
+	return nil
+}
+
+func (grpcServer *BasicGrpcServer) enableSzDiagnostic(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error {
+	server := &szdiagnosticserver.SzDiagnosticServer{}
+	err := server.SetLogLevel(ctx, grpcServer.LogLevelName)
+	if err != nil {
+		return err
+	}
+	sdk := szdiagnosticserver.GetSdkSzDiagnostic()
+	err = sdk.Initialize(ctx, grpcServer.SenzingInstanceName, grpcServer.SenzingSettings, senzing.SzInitializeWithDefaultConfiguration, grpcServer.SenzingVerboseLogging)
+	if err != nil {
+		return err
+	}
+	grpcServer.initialized = append(grpcServer.initialized, sdk)
+	for _, observer := range grpcServer.Observers {
+		err = server.RegisterObserver(ctx, observer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(grpcServer.ObserverOrigin) > 0 {
+		server.SetObserverOrigin(ctx, grpcServer.ObserverOrigin)
+	}
+	szdiagnostic.RegisterSzDiagnosticServer(serviceRegistrar, server)
+	return nil
+}
+
+func (grpcServer *BasicGrpcServer) enableSzEngine(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error {
+	server := &szengineserver.SzEngineServer{}
+	err := server.SetLogLevel(ctx, grpcServer.LogLevelName)
+	if err != nil {
+		return err
+	}
+	sdk := szengineserver.GetSdkSzEngine()
+	err = sdk.Initialize(ctx, grpcServer.SenzingInstanceName, grpcServer.SenzingSettings, senzing.SzInitializeWithDefaultConfiguration, grpcServer.SenzingVerboseLogging)
+	if err != nil {
+		return err
+	}
+	grpcServer.initialized = append(grpcServer.initialized, sdk)
+	for _, observer := range grpcServer.Observers {
+		err = server.RegisterObserver(ctx, observer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(grpcServer.ObserverOrigin) > 0 {
+		server.SetObserverOrigin(ctx, grpcServer.ObserverOrigin)
+	}
+	szengine.RegisterSzEngineServer(serviceRegistrar, server)
+	return nil
+}
+
+func (grpcServer *BasicGrpcServer) enableSzProduct(ctx context.Context, serviceRegistrar grpc.ServiceRegistrar) error {
+	server := &szproductserver.SzProductServer{}
+	err := server.SetLogLevel(ctx, grpcServer.LogLevelName)
+	if err != nil {
+		return err
+	}
+	sdk := szproductserver.GetSdkSzProduct()
+	err = sdk.Initialize(ctx, grpcServer.SenzingInstanceName, grpcServer.SenzingSettings, grpcServer.SenzingVerboseLogging)
+	if err != nil {
+		return err
+	}
+	grpcServer.initialized = append(grpcServer.initialized, sdk)
+	for _, observer := range grpcServer.Observers {
+		err = server.RegisterObserver(ctx, observer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(grpcServer.ObserverOrigin) > 0 {
+		server.SetObserverOrigin(ctx, grpcServer.ObserverOrigin)
+	}
+	szproduct.RegisterSzProductServer(serviceRegistrar, server)
+	return nil
+}
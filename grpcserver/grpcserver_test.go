@@ -0,0 +1,292 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/settings"
+	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/playground/selfsigned"
+	"github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicGrpcServer_Serve(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	err := grpcServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicGrpcServer_Serve_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.AvoidServing = false
+	grpcServer.Port = 0
+	time.AfterFunc(100*time.Millisecond, cancel)
+	err := grpcServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicGrpcServer_Serve_avoidListening(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.AvoidListening = true
+	grpcServer.AvoidServing = false
+	time.AfterFunc(100*time.Millisecond, cancel)
+	err := grpcServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicGrpcServer_DialContext(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.AvoidServing = false
+	grpcServer.Port = 0
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- grpcServer.Serve(ctx)
+	}()
+	grpcConnection, err := grpc.NewClient("passthrough:///in-process",
+		grpc.WithContextDialer(grpcServer.DialContext),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer grpcConnection.Close()
+	_, err = szproduct.NewSzProductClient(grpcConnection).GetVersion(ctx, &szproduct.GetVersionRequest{}, grpc.WaitForReady(true))
+	require.NoError(test, err)
+	healthResponse, err := grpc_health_v1.NewHealthClient(grpcConnection).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err)
+	assert.Equal(test, grpc_health_v1.HealthCheckResponse_SERVING, healthResponse.GetStatus())
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicGrpcServer_ServeHTTP_notServing(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	request := httptest.NewRequest(http.MethodPost, "/szproduct.SzProduct/GetVersion", nil)
+	response := httptest.NewRecorder()
+	grpcServer.ServeHTTP(response, request)
+	assert.Equal(test, strconv.Itoa(int(codes.Unavailable)), response.Header().Get("Grpc-Status"))
+}
+
+func TestBasicGrpcServer_Serve_tls(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.AvoidServing = false
+	grpcServer.Port = 0
+	grpcServer.TLSCertFile, grpcServer.TLSKeyFile, grpcServer.TLSClientCAFile = writeTestCertificates(test)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	err := grpcServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicGrpcServer_Reinitialize_notServing(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	err := grpcServer.Reinitialize(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicGrpcServer_WithoutCalls(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.AvoidListening = true
+	grpcServer.AvoidServing = false
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- grpcServer.Serve(ctx)
+	}()
+	grpcConnection, err := grpc.NewClient("passthrough:///in-process",
+		grpc.WithContextDialer(grpcServer.DialContext),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer grpcConnection.Close()
+	client := szproduct.NewSzProductClient(grpcConnection)
+	_, err = client.GetVersion(ctx, &szproduct.GetVersionRequest{}, grpc.WaitForReady(true))
+	require.NoError(test, err)
+
+	// A call made while WithoutCalls runs waits for it to return.
+
+	called := make(chan error, 1)
+	err = grpcServer.WithoutCalls(ctx, func(ctx context.Context) error {
+		go func() {
+			_, err := client.GetVersion(ctx, &szproduct.GetVersionRequest{})
+			called <- err
+		}()
+		select {
+		case <-called:
+			require.Fail(test, "call ran while WithoutCalls held calls back")
+		case <-time.After(100 * time.Millisecond):
+		}
+		return nil
+	})
+	require.NoError(test, err)
+	require.NoError(test, <-called)
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicGrpcServer_WithoutCalls_cancel(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.exclusive.RLock()
+	cancelledCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err := grpcServer.WithoutCalls(cancelledCtx, func(ctx context.Context) error {
+		require.Fail(test, "ran while a call was in flight")
+		return nil
+	})
+	require.ErrorIs(test, err, context.DeadlineExceeded)
+	grpcServer.exclusive.RUnlock()
+	err = grpcServer.WithoutCalls(ctx, func(ctx context.Context) error {
+		return nil
+	})
+	require.NoError(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestChangesData(test *testing.T) {
+	assert.True(test, ChangesData("/szengine.SzEngine/AddRecord"))
+	assert.True(test, ChangesData("/szdiagnostic.SzDiagnostic/PurgeRepository"))
+	assert.False(test, ChangesData("/szengine.SzEngine/GetEntityByEntityId"))
+	assert.False(test, ChangesData(grpc_health_v1.Health_Check_FullMethodName))
+}
+
+func TestMethodRole(test *testing.T) {
+	assert.Equal(test, authentication.RoleNone, MethodRole(grpc_health_v1.Health_Check_FullMethodName))
+	assert.Equal(test, authentication.RoleViewer, MethodRole(szproduct.SzProduct_GetVersion_FullMethodName))
+	assert.Equal(test, authentication.RoleViewer, MethodRole("/szengine.SzEngine/GetEntityByEntityId"))
+	assert.Equal(test, authentication.RoleLoader, MethodRole("/szengine.SzEngine/AddRecord"))
+	assert.Equal(test, authentication.RoleLoader, MethodRole("/szconfigmanager.SzConfigManager/SetDefaultConfigId"))
+	assert.Equal(test, authentication.RoleLoader, MethodRole("/szengine.SzEngine/Reinitialize"))
+	assert.Equal(test, authentication.RoleAdmin, MethodRole("/szdiagnostic.SzDiagnostic/PurgeRepository"))
+	assert.Equal(test, authentication.RoleAdmin, MethodRole("/unknown.Service/Method"))
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPCalls_stop(test *testing.T) {
+	calls := &httpCalls{}
+	assert.Nil(test, calls.acquire())
+	calls.start(grpc.NewServer())
+	require.NotNil(test, calls.acquire())
+	drained := calls.stop()
+	assert.Nil(test, calls.acquire())
+	select {
+	case <-drained:
+		require.Fail(test, "drained before the in-flight call was released")
+	default:
+	}
+	calls.release()
+	<-drained
+}
+
+func TestBasicGrpcServer_getTLSConfig(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.TLSCertFile, grpcServer.TLSKeyFile, grpcServer.TLSClientCAFile = writeTestCertificates(test)
+	tlsConfig, err := grpcServer.getTLSConfig()
+	require.NoError(test, err)
+	require.Equal(test, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+}
+
+func TestBasicGrpcServer_getTLSConfig_badClientCAFile(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.TLSCertFile, grpcServer.TLSKeyFile, _ = writeTestCertificates(test)
+	grpcServer.TLSClientCAFile = grpcServer.TLSKeyFile
+	_, err := grpcServer.getTLSConfig()
+	require.ErrorIs(test, err, ErrNoCertificates)
+}
+
+func TestBasicGrpcServer_getTLSConfig_missingCertFile(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.TLSClientCAFile = "/tmp/ca.pem"
+	_, err := grpcServer.getTLSConfig()
+	require.ErrorIs(test, err, ErrMissingTLSCertFile)
+}
+
+func TestBasicGrpcServer_gracefulStop(test *testing.T) {
+	ctx := context.TODO()
+	grpcServer := getTestObject(ctx, test)
+	grpcServer.ShutdownTimeout = time.Second
+	err := grpcServer.gracefulStop(grpc.NewServer(), grpc.NewServer())
+	require.NoError(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Write a CA and a server certificate signed by it.  Return the certificate, key, and CA file paths.
+func writeTestCertificates(test *testing.T) (string, string, string) {
+	directory := test.TempDir()
+	caPEM, caKeyPEM, err := selfsigned.GenerateCertificateAuthority("Test CA")
+	require.NoError(test, err)
+	certificatePEM, keyPEM, err := selfsigned.GenerateSignedBy(caPEM, caKeyPEM, "server", []string{"localhost"})
+	require.NoError(test, err)
+	certificateFile := filepath.Join(directory, "server.pem")
+	keyFile := filepath.Join(directory, "server-key.pem")
+	caFile := filepath.Join(directory, "ca.pem")
+	require.NoError(test, os.WriteFile(certificateFile, certificatePEM, 0600))
+	require.NoError(test, os.WriteFile(keyFile, keyPEM, 0600))
+	require.NoError(test, os.WriteFile(caFile, caPEM, 0600))
+	return certificateFile, keyFile, caFile
+}
+
+func getTestObject(ctx context.Context, test *testing.T) *BasicGrpcServer {
+	_ = ctx
+
+	observer1 := &observer.NullObserver{
+		ID: "Observer 1",
+	}
+
+	logLevelName := "INFO"
+	osenvLogLevel := os.Getenv("SENZING_LOG_LEVEL")
+	if len(osenvLogLevel) > 0 {
+		logLevelName = osenvLogLevel
+	}
+
+	senzingSettings, err := settings.BuildSimpleSettingsUsingEnvVars()
+	require.NoError(test, err)
+
+	result := &BasicGrpcServer{
+		AvoidServing:        true,
+		LogLevelName:        logLevelName,
+		ObserverOrigin:      "Test Observer origin",
+		Observers:           []observer.Observer{observer1},
+		Port:                8258,
+		SenzingInstanceName: "Test gRPC Server",
+		SenzingSettings:     senzingSettings,
+		ShutdownTimeout:     5 * time.Second,
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/sz-sdk-proto/go/szconfig"
+	"github.com/senzing-garage/sz-sdk-proto/go/szconfigmanager"
+	"github.com/senzing-garage/sz-sdk-proto/go/szdiagnostic"
+	"github.com/senzing-garage/sz-sdk-proto/go/szengine"
+	"github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	grpc_reflection_v1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	grpc_reflection_v1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The GrpcServer interface...
+type GrpcServer interface {
+	DialContext(ctx context.Context, target string) (net.Conn, error)
+	Reinitialize(ctx context.Context) error
+	Serve(ctx context.Context) error
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	WithoutCalls(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identfier of the  package found messages having the format "senzing-6211xxxx".
+const ComponentID = 6211
+
+// Bytes buffered in each direction of an in-process connection.
+const inProcessBufferSize = 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrShutdownTimeout is returned by Serve when in-flight calls did not finish
+// before the ShutdownTimeout elapsed and the server had to be stopped forcibly.
+var ErrShutdownTimeout = errors.New("grpcserver: in-flight calls did not finish before the shutdown timeout")
+
+// ErrMissingTLSCertFile is returned by Serve when TLSClientCAFile is set without TLSCertFile.
+var ErrMissingTLSCertFile = errors.New("grpcserver: client certificate verification requires TLSCertFile")
+
+// ErrNoCertificates is returned by Serve when TLSClientCAFile holds no PEM certificates.
+var ErrNoCertificates = errors.New("grpcserver: no PEM certificates found in TLSClientCAFile")
+
+// ErrNoConfigManager is returned by Reinitialize when the SzConfigManager service, which reads the default configuration, is not enabled.
+var ErrNoConfigManager = errors.New("grpcserver: reinitializing requires the SzConfigManager service")
+
+// Message templates.
+var IDMessages = map[int]string{
+	2000: "Entry: %+v",
+	2003: "Server listening at %v",
+	2004: "Serving avoided.",
+	2005: "Shutting down gRPC server. Waiting up to %v for in-flight calls to finish.",
+	2006: "gRPC server stopped.",
+	2007: "Serving gRPC through the HTTP server.",
+	3001: "In-flight gRPC calls did not finish within %v. Forcing stop.",
+	4001: "Call to net.Listen(tcp, %s) failed.",
+	4003: "Call to %s.Destroy() failed.",
+	4004: "Could not load TLS certificate %s and key %s.",
+	4005: "Could not load client CA certificates from %s.",
+	4006: "In-process gRPC server failed.",
+}
+
+// Status strings for specific messages.
+var IDStatuses = map[int]string{}
+
+// Role required to call each method that is not read-only.  See MethodRole.
+// Reinitialize is RoleLoader's, as loaders may replace the default configuration, which it loads.
+var methodRoles = map[string]string{
+	szconfig.SzConfig_AddDataSource_FullMethodName:                        authentication.RoleLoader,
+	szconfig.SzConfig_DeleteDataSource_FullMethodName:                     authentication.RoleLoader,
+	szconfig.SzConfig_ImportConfig_FullMethodName:                         authentication.RoleLoader,
+	szconfigmanager.SzConfigManager_AddConfig_FullMethodName:              authentication.RoleLoader,
+	szconfigmanager.SzConfigManager_ReplaceDefaultConfigId_FullMethodName: authentication.RoleLoader,
+	szconfigmanager.SzConfigManager_SetDefaultConfigId_FullMethodName:     authentication.RoleLoader,
+	szdiagnostic.SzDiagnostic_CheckDatastorePerformance_FullMethodName:    authentication.RoleLoader,
+	szdiagnostic.SzDiagnostic_PurgeRepository_FullMethodName:              authentication.RoleAdmin,
+	szdiagnostic.SzDiagnostic_Reinitialize_FullMethodName:                 authentication.RoleLoader,
+	szengine.SzEngine_AddRecord_FullMethodName:                            authentication.RoleLoader,
+	szengine.SzEngine_DeleteRecord_FullMethodName:                         authentication.RoleLoader,
+	szengine.SzEngine_GetRedoRecord_FullMethodName:                        authentication.RoleLoader,
+	szengine.SzEngine_ProcessRedoRecord_FullMethodName:                    authentication.RoleLoader,
+	szengine.SzEngine_ReevaluateEntity_FullMethodName:                     authentication.RoleLoader,
+	szengine.SzEngine_ReevaluateRecord_FullMethodName:                     authentication.RoleLoader,
+	szengine.SzEngine_Reinitialize_FullMethodName:                         authentication.RoleLoader,
+}
+
+// Role required to call the other methods of each service.  Services not listed require RoleAdmin.
+var serviceRoles = map[string]string{
+	grpc_health_v1.Health_ServiceDesc.ServiceName:                    authentication.RoleNone,
+	grpc_reflection_v1.ServerReflection_ServiceDesc.ServiceName:      authentication.RoleViewer,
+	grpc_reflection_v1alpha.ServerReflection_ServiceDesc.ServiceName: authentication.RoleViewer,
+	szconfig.SzConfig_ServiceDesc.ServiceName:                        authentication.RoleViewer,
+	szconfigmanager.SzConfigManager_ServiceDesc.ServiceName:          authentication.RoleViewer,
+	szdiagnostic.SzDiagnostic_ServiceDesc.ServiceName:                authentication.RoleViewer,
+	szengine.SzEngine_ServiceDesc.ServiceName:                        authentication.RoleViewer,
+	szproduct.SzProduct_ServiceDesc.ServiceName:                      authentication.RoleViewer,
+}
@@ -0,0 +1,5 @@
+/*
+Package healthprobe periodically checks the services the playground depends on
+and reports whether each is up, degraded, or down.
+*/
+package healthprobe
@@ -0,0 +1,196 @@
+package healthprobe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicHealthProber_Serve(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	prober := getTestObject(test)
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- prober.Serve(ctx)
+	}()
+	require.Eventually(test, func() bool {
+		for _, status := range prober.Status() {
+			if status.State == StateUnknown {
+				return false
+			}
+		}
+		return len(prober.Status()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(test, <-serveErrors)
+
+	status := prober.Status()
+	assert.Equal(test, "up", status[0].Name)
+	assert.Equal(test, StateUp, status[0].State)
+	assert.Empty(test, status[0].LastError)
+	assert.False(test, status[0].Checked.IsZero())
+	assert.Equal(test, StateDown, status[1].State)
+	assert.Equal(test, "broken", status[1].LastError)
+	assert.False(test, status[1].LastErrorAt.IsZero())
+	assert.Equal(test, StateDegraded, status[2].State)
+	assert.Empty(test, status[2].LastError)
+	assert.GreaterOrEqual(test, status[2].Latency, prober.DegradedLatency)
+	assert.Equal(test, StateDegraded, status[3].State)
+	assert.Contains(test, status[3].LastError, "overloaded")
+}
+
+func TestBasicHealthProber_Serve_recovers(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	calls := make(chan struct{}, 100)
+	prober := &BasicHealthProber{
+		Interval: 10 * time.Millisecond,
+		Probes: []Probe{{Name: "flaky", Check: func(ctx context.Context) error {
+			calls <- struct{}{}
+			if len(calls) == 1 {
+				return errors.New("starting")
+			}
+			return nil
+		}}},
+	}
+	go func() {
+		_ = prober.Serve(ctx)
+	}()
+	require.Eventually(test, func() bool {
+		status := prober.Status()
+		return len(status) == 1 && status[0].State == StateUp
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(test, "starting", prober.Status()[0].LastError)
+}
+
+func TestBasicHealthProber_Serve_invalidProbes(test *testing.T) {
+	ctx := context.TODO()
+	check := func(ctx context.Context) error { return nil }
+	testCases := []struct {
+		name     string
+		probes   []Probe
+		expected error
+	}{
+		{name: "duplicate name", probes: []Probe{{Name: "a", Check: check}, {Name: "a", Check: check}}, expected: ErrDuplicateName},
+		{name: "missing check", probes: []Probe{{Name: "a"}}, expected: ErrMissingCheck},
+		{name: "missing name", probes: []Probe{{Check: check}}, expected: ErrMissingName},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			prober := &BasicHealthProber{Probes: testCase.probes}
+			require.ErrorIs(test, prober.Serve(ctx), testCase.expected)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestCheckCommand(test *testing.T) {
+	ctx := context.TODO()
+	require.NoError(test, CheckCommand("sh")(ctx))
+	require.Error(test, CheckCommand("no-such-command-for-healthprobe")(ctx))
+}
+
+func TestCheckDatabase(test *testing.T) {
+	ctx := context.TODO()
+	filename := filepath.Join(test.TempDir(), "G2C.db")
+	databaseURL := "sqlite3://na:na@" + filename
+	require.Error(test, CheckDatabase(databaseURL)(ctx))
+	assert.NoFileExists(test, filename)
+	require.NoError(test, os.WriteFile(filename, []byte{}, 0600))
+	require.NoError(test, CheckDatabase(databaseURL)(ctx))
+	require.Error(test, CheckDatabase("bad://")(ctx))
+}
+
+func TestCheckGrpcHealth(test *testing.T) {
+	ctx := context.TODO()
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+	connection, err := grpc.NewClient("passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer connection.Close()
+
+	require.NoError(test, CheckGrpcHealth(connection, "")(ctx))
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	require.Error(test, CheckGrpcHealth(connection, "")(ctx))
+	require.Error(test, CheckGrpcHealth(connection, "missing")(ctx))
+}
+
+func TestCheckHTTP(test *testing.T) {
+	ctx := context.TODO()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+	require.NoError(test, CheckHTTP(nil, server.URL+"/ok")(ctx))
+	err := CheckHTTP(server.Client(), server.URL+"/missing")(ctx)
+	require.ErrorIs(test, err, ErrDegraded)
+	err = CheckHTTP(server.Client(), server.URL+"/broken")(ctx)
+	require.Error(test, err)
+	require.NotErrorIs(test, err, ErrDegraded)
+	server.Close()
+	require.Error(test, CheckHTTP(nil, server.URL+"/ok")(ctx))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// One probe in each state: up, down, degraded by latency, and degraded by error.
+func getTestObject(test *testing.T) *BasicHealthProber {
+	_ = test
+	return &BasicHealthProber{
+		DegradedLatency: 20 * time.Millisecond,
+		Interval:        time.Hour,
+		Probes: []Probe{
+			{Name: "up", Check: func(ctx context.Context) error { return nil }},
+			{Name: "down", Check: func(ctx context.Context) error { return errors.New("broken") }},
+			{Name: "slow", Check: func(ctx context.Context) error {
+				time.Sleep(30 * time.Millisecond)
+				return nil
+			}},
+			{Name: "overloaded", Check: func(ctx context.Context) error { return fmt.Errorf("overloaded: %w", ErrDegraded) }},
+		},
+		Timeout: time.Second,
+	}
+}
@@ -0,0 +1,88 @@
+package healthprobe
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The HealthProber interface...
+type HealthProber interface {
+	Serve(ctx context.Context) error
+	Status() []ServiceStatus
+}
+
+// A CheckFunc returns nil if the service is healthy.  To report the service as
+// degraded rather than down, return an error that wraps ErrDegraded.
+type CheckFunc func(ctx context.Context) error
+
+// Probe describes a service to check.
+type Probe struct {
+	Check CheckFunc
+	Name  string
+}
+
+// ServiceStatus reports the result of the latest check of a Probe.
+type ServiceStatus struct {
+	Checked     time.Time
+	LastError   string
+	LastErrorAt time.Time
+	Latency     time.Duration
+	Name        string
+	State       string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identfier of the  package found messages having the format "senzing-6213xxxx".
+const ComponentID = 6213
+
+// Default latency above which a successful check reports the service as degraded.
+const DefaultDegradedLatency = time.Second
+
+// Default time between checks.
+const DefaultInterval = 10 * time.Second
+
+// Default time allowed for a single check.
+const DefaultTimeout = 5 * time.Second
+
+// Service states.
+const (
+	StateDegraded = "degraded"
+	StateDown     = "down"
+	StateUnknown  = "unknown"
+	StateUp       = "up"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrDegraded is wrapped by a CheckFunc's error when the service responds, but not normally.
+var ErrDegraded = errors.New("healthprobe: service is degraded")
+
+// ErrDuplicateName is returned by Serve when two probes have the same Name.
+var ErrDuplicateName = errors.New("healthprobe: probe names must be unique")
+
+// ErrMissingCheck is returned by Serve when a probe has no Check.
+var ErrMissingCheck = errors.New("healthprobe: probe has no check")
+
+// ErrMissingName is returned by Serve when a probe has no Name.
+var ErrMissingName = errors.New("healthprobe: probe has no name")
+
+// Message templates.
+var IDMessages = map[int]string{
+	2001: "Service %s is up.",
+	3001: "Service %s is degraded. Latency: %v.",
+	3002: "Service %s is degraded.",
+	4001: "Service %s is down.",
+}
+
+// Status strings for specific messages.
+var IDStatuses = map[int]string{}
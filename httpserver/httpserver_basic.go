@@ -4,23 +4,56 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/tls"
 	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/docktermj/cloudshell/xtermservice"
 	"github.com/flowchartsman/swaggerui"
 	"github.com/pkg/browser"
 	"github.com/senzing-garage/demo-entity-search/entitysearchservice"
+	"github.com/senzing-garage/go-helpers/settingsparser"
+	"github.com/senzing-garage/go-logging/logging"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-rest-api-service-legacy/restapiservicelegacy"
 	"github.com/senzing-garage/go-rest-api-service/senzingrestapi"
+	"github.com/senzing-garage/go-rest-api-service/senzingrestservice"
+	"github.com/senzing-garage/go-sdk-abstract-factory/szfactorycreator"
+	"github.com/senzing-garage/playground/audit"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/playground/connectbridge"
+	"github.com/senzing-garage/playground/grpcserver"
+	"github.com/senzing-garage/playground/healthprobe"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/senzing-garage/playground/metrics"
+	"github.com/senzing-garage/playground/observing"
+	"github.com/senzing-garage/playground/selfsigned"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/senzing-garage/playground/supervisor"
+	"github.com/senzing-garage/playground/tracing"
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/senzing-garage/sz-sdk-proto/go/szproduct"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 )
 
@@ -30,20 +63,40 @@ import (
 
 // BasicHTTPServer is the default implementation of the HttpServer interface.
 type BasicHTTPServer struct {
-	APIUrlRoutePrefix         string // FIXME: Only works with "api"
+	APIUrlRoutePrefix         string
+	Audit                     *audit.BasicAudit
+	Authentication            *authentication.BasicAuthentication
 	AvoidServing              bool
+	BasePath                  string
+	ConnectRoutePrefix        string
 	EnableAll                 bool
+	EnableConnect             bool
 	EnableEntitySearch        bool
 	EnableJupyterLab          bool
 	EnableSenzingRestAPI      bool
 	EnableSwaggerUI           bool
 	EnableXterm               bool
-	EntitySearchRoutePrefix   string // FIXME: Only works with "entity-search"
+	EntitySearchRoutePrefix   string
+	eventStreamsDone          chan struct{}
+	GoRestAPI                 bool
+	grpcConnection            *grpc.ClientConn
 	GrpcDialOptions           []grpc.DialOption
+	GrpcHandler               http.Handler
+	GrpcPort                  int
 	GrpcTarget                string
+	GrpcTLS                   bool
+	GrpcTLSCAFile             string
+	GrpcTLSClientCertFile     string
+	GrpcTLSClientKeyFile      string
+	HealthProbeInterval       time.Duration
+	healthProber              healthprobe.HealthProber
+	HTTPRedirectPort          int
 	IsInDevelopment           bool
-	JupyterLabRoutePrefix     string // FIXME: Only works with "jupyter"
+	JupyterLabRoutePrefix     string
+	logger                    logging.Logging
 	LogLevelName              string
+	Metrics                   *metrics.BasicMetrics
+	Observations              *observing.BufferObserver
 	ObserverOrigin            string
 	Observers                 []observer.Observer
 	OpenAPISpecificationRest  []byte
@@ -54,33 +107,100 @@ type BasicHTTPServer struct {
 	ServerAddress             string
 	ServerOptions             []senzingrestapi.ServerOption
 	ServerPort                int
-	SwaggerURLRoutePrefix     string // FIXME: Only works with "swagger"
+	ShutdownTimeout           time.Duration
+	Snapshots                 *snapshot.BasicSnapshots
+	Supervisor                supervisor.Supervisor
+	SwaggerURLRoutePrefix     string
+	TLSCertFile               string
+	TLSKeyFile                string
+	TLSSelfSigned             bool
+	Tracing                   *tracing.BasicTracing
 	TtyOnly                   bool
+	UploadMaxCount            int
+	UploadMaxSize             int64
+	uploads                   *uploads
+	Version                   string
 	XtermAllowedHostnames     []string
 	XtermArguments            []string
 	XtermCommand              string
 	XtermConnectionErrorLimit int
 	XtermKeepalivePingTimeout int
 	XtermMaxBufferSizeBytes   int
-	XtermURLRoutePrefix       string // FIXME: Only works with "xterm"
+	XtermURLRoutePrefix       string
+	xtermSessions             *xtermSessions
 }
 
 type TemplateVariables struct {
 	APIServerStatus string
 	APIServerURL    string
+	Attributes      []string
 	BasicHTTPServer
+	ConnectURL         string
 	EntitySearchStatus string
 	EntitySearchURL    string
+	GrpcChannel        string
+	Health             []healthprobe.ServiceStatus
 	HTMLTitle          string
 	JupyterLabStatus   string
 	JupyterLabURL      string
+	Programs           []supervisor.ProgramStatus
 	RequestHost        string
+	RootPath           string
 	SwaggerStatus      string
 	SwaggerURL         string
 	XtermStatus        string
 	XtermURL           string
 }
 
+// accessRecorder remembers the status code and size of the response written through it.
+type accessRecorder struct {
+	http.ResponseWriter
+	bytes      int64
+	statusCode int
+}
+
+// bufferedResponse holds a response in memory, so it can be rewritten before it is sent.
+type bufferedResponse struct {
+	body       bytes.Buffer
+	header     http.Header
+	statusCode int
+}
+
+// xtermSessions tracks the connections of open xterm websockets.
+// http.Server.Shutdown does not wait for, or close, hijacked connections.
+type xtermSessions struct {
+	audit       *audit.BasicAudit
+	connections map[net.Conn]struct{}
+	metrics     *metrics.BasicMetrics
+	mutex       sync.Mutex
+}
+
+// uploads keeps the files uploaded to "/upload", in a directory created on first use.
+// One file is loaded at a time, and not while a snapshot is being restored.
+// ids lists the uploads oldest first, so the oldest can be removed to make room.
+type uploads struct {
+	dir         string
+	entries     map[string]*upload
+	ids         []string
+	isLoading   bool
+	isRestoring bool
+	loads       sync.WaitGroup
+	mutex       sync.Mutex
+}
+
+// upload is a file uploaded to "/upload", converted to JSON Lines.
+// The original of a CSV or TSV file is kept, to be converted again with another mapping.
+type upload struct {
+	failuresFile string
+	file         string
+	original     string
+	status       UploadStatus
+}
+
+type contextKey int
+
+const connectionContextKey contextKey = iota
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
@@ -93,115 +213,348 @@ var static embed.FS
 // ----------------------------------------------------------------------------
 
 /*
-The Serve method simply prints the 'Something' value in the type-struct.
+The Serve method serves the console and the enabled sub-services on ServerPort,
+over HTTPS when TLSCertFile or TLSSelfSigned is set, and blocks until it stops.
+When ctx is cancelled, open xterm sessions are hung up, the server stops
+accepting connections, and in-flight requests are given up to ShutdownTimeout
+to finish.  A ShutdownTimeout of zero waits indefinitely.
 
 Input
   - ctx: A context to control lifecycle.
 
 Output
-  - Nothing is returned, except for an error.  However, something is printed.
-    See the example output.
+  - nil after a clean shutdown, ErrShutdownTimeout if in-flight requests had
+    to be abandoned, otherwise the error that stopped the server.
 */
 
 func (httpServer *BasicHTTPServer) Serve(ctx context.Context) error {
 	rootMux := http.NewServeMux()
 	var userMessage string
+	_ = httpServer.getLogger()
+	scheme := httpServer.getScheme()
+	httpServer.xtermSessions = &xtermSessions{
+		audit:       httpServer.Audit,
+		connections: map[net.Conn]struct{}{},
+		metrics:     httpServer.Metrics,
+	}
+	httpServer.eventStreamsDone = make(chan struct{})
+
+	// Remember which sub-service each route belongs to, for access logs.
+
+	routeServices := map[string]string{}
+	handle := func(pattern string, service string, handler http.Handler) {
+		rootMux.Handle(pattern, handler)
+		routeServices[pattern] = service
+	}
 
 	// Enable Senzing HTTP REST API.
 
 	if httpServer.EnableAll || httpServer.EnableSenzingRestAPI {
-		senzingAPIMux := httpServer.getSenzingRestAPIMux(ctx)
-		rootMux.Handle(fmt.Sprintf("/%s/", httpServer.APIUrlRoutePrefix), http.StripPrefix("/api", senzingAPIMux))
-		userMessage = fmt.Sprintf("%sServing Senzing REST API at http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, httpServer.APIUrlRoutePrefix)
+		apiPath := httpServer.routePath(httpServer.APIUrlRoutePrefix)
+		senzingAPIMux, err := httpServer.getSenzingRestAPIMux(ctx)
+		if err != nil {
+			return err
+		}
+		handle(apiPath+"/", serviceSenzingRestAPI, http.StripPrefix(apiPath, senzingAPIMux))
+		userMessage = fmt.Sprintf("%sServing Senzing REST API at %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, apiPath)
 	}
 
 	// Enable Senzing HTTP REST API as reverse proxy.
 
 	if httpServer.EnableAll || httpServer.EnableSenzingRestAPI || httpServer.EnableEntitySearch {
-		senzingAPIProxyMux := httpServer.getSenzingRestAPIProxyMux(ctx)
-		rootMux.Handle("/entity-search/api/", http.StripPrefix("/entity-search/api", senzingAPIProxyMux))
-		userMessage = fmt.Sprintf("%sServing Senzing REST API Reverse Proxy at http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, "entity-search/api")
+		apiProxyPath := httpServer.routePath(httpServer.EntitySearchRoutePrefix) + "/api"
+		senzingAPIProxyMux, err := httpServer.getSenzingRestAPIProxyMux(ctx)
+		if err != nil {
+			return err
+		}
+		handle(apiProxyPath+"/", serviceSenzingRestAPI, http.StripPrefix(apiProxyPath, senzingAPIProxyMux))
+		userMessage = fmt.Sprintf("%sServing Senzing REST API Reverse Proxy at %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, apiProxyPath)
 	}
 
 	// Enable Senzing Entity Search.
 
 	if httpServer.EnableAll || httpServer.EnableEntitySearch {
+		entitySearchPath := httpServer.routePath(httpServer.EntitySearchRoutePrefix)
 		entitySearchMux := httpServer.getEntitySearchMux(ctx)
-		rootMux.Handle(fmt.Sprintf("/%s/", httpServer.EntitySearchRoutePrefix), http.StripPrefix("/entity-search", entitySearchMux))
-		userMessage = fmt.Sprintf("%sServing Entity Search at    http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, httpServer.EntitySearchRoutePrefix)
+		handle(entitySearchPath+"/", serviceEntitySearch, http.StripPrefix(entitySearchPath, entitySearchMux))
+		userMessage = fmt.Sprintf("%sServing Entity Search at    %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, entitySearchPath)
 	}
 
 	// Enable SwaggerUI.
 
 	if httpServer.EnableAll || httpServer.EnableSwaggerUI {
+		swaggerPath := httpServer.routePath(httpServer.SwaggerURLRoutePrefix)
 		swaggerUIMux := httpServer.getSwaggerUIMux(ctx)
-		rootMux.Handle(fmt.Sprintf("/%s/", httpServer.SwaggerURLRoutePrefix), http.StripPrefix("/swagger", swaggerUIMux))
-		userMessage = fmt.Sprintf("%sServing SwaggerUI at        http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, httpServer.SwaggerURLRoutePrefix)
+		handle(swaggerPath+"/", serviceSwaggerUI, http.StripPrefix(swaggerPath, swaggerUIMux))
+		userMessage = fmt.Sprintf("%sServing SwaggerUI at        %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, swaggerPath)
+	}
+
+	// Enable the Connect protocol, so console pages can call the gRPC services with fetch().
+
+	if httpServer.isConnectEnabled() {
+		connectPath := httpServer.routePath(httpServer.ConnectRoutePrefix)
+		connectBridge := &connectbridge.BasicConnectBridge{
+			GrpcDialOptions: httpServer.GrpcDialOptions,
+			GrpcTarget:      httpServer.GrpcTarget,
+		}
+		defer connectBridge.Close()
+		handle(connectPath+"/", serviceConnect, http.StripPrefix(connectPath, connectBridge))
+		userMessage = fmt.Sprintf("%sServing Connect at          %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, connectPath)
 	}
 
-	// Enable JupyterLab.
+	// Enable JupyterLab.  Requests are proxied unchanged,
+	// so JupyterLab's base_url must be the same path.
 
 	if httpServer.EnableAll || httpServer.EnableJupyterLab {
-		proxy, err := newReverseProxy("http://localhost:8888")
+		jupyterLabPath := httpServer.routePath(httpServer.JupyterLabRoutePrefix)
+		proxy, err := newReverseProxy(jupyterLabTarget)
 		if err != nil {
-			panic(err)
+			return err
 		}
-		rootMux.HandleFunc(fmt.Sprintf("/%s/", httpServer.JupyterLabRoutePrefix), reverseProxyRequestHandler(proxy))
-		userMessage = fmt.Sprintf("%sServing JupyterLab at       http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, httpServer.JupyterLabRoutePrefix)
+		proxy.ErrorHandler = httpServer.proxyErrorHandler(jupyterLabTarget)
+		proxy.Transport = httpServer.Tracing.InstrumentTransport(serviceJupyterLab, httpServer.Metrics.InstrumentTransport(serviceJupyterLab, nil))
+		handle(jupyterLabPath+"/", serviceJupyterLab, http.HandlerFunc(reverseProxyRequestHandler(proxy)))
+		userMessage = fmt.Sprintf("%sServing JupyterLab at       %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, jupyterLabPath)
 	}
 
 	// Enable Xterm.
 
 	if httpServer.EnableAll || httpServer.EnableXterm {
+		xtermPath := httpServer.routePath(httpServer.XtermURLRoutePrefix)
 		err := os.Setenv("SENZING_ENGINE_CONFIGURATION_JSON", httpServer.SenzingSettings)
 		if err != nil {
-			panic(err)
+			return err
 		}
 		xtermMux := httpServer.getXtermMux(ctx)
-		rootMux.Handle(fmt.Sprintf("/%s/", httpServer.XtermURLRoutePrefix), http.StripPrefix("/xterm", xtermMux))
-		userMessage = fmt.Sprintf("%sServing XTerm at            http://localhost:%d/%s\n", userMessage, httpServer.ServerPort, httpServer.XtermURLRoutePrefix)
+		handle(xtermPath+"/", serviceXterm, http.StripPrefix(xtermPath, httpServer.xtermSessions.track(xtermMux)))
+		userMessage = fmt.Sprintf("%sServing XTerm at            %s://localhost:%d%s\n", userMessage, scheme, httpServer.ServerPort, xtermPath)
+	}
+
+	// Add routes for liveness, readiness, and detailed status.
+
+	rootPath := httpServer.rootPath()
+	handle(rootPath+"/healthz", serviceHealth, http.HandlerFunc(httpServer.handleFuncForHealthz))
+	handle(rootPath+"/readyz", serviceHealth, http.HandlerFunc(httpServer.handleFuncForReadyz))
+	handle(rootPath+"/status", serviceStatus, http.HandlerFunc(httpServer.handleFuncForStatus))
+
+	// Add route for Prometheus metrics.
+
+	if httpServer.Metrics != nil {
+		handle(rootPath+"/metrics", serviceMetrics, httpServer.Metrics.Handler())
+		userMessage = fmt.Sprintf("%sServing Metrics at          %s://localhost:%d%s/metrics\n", userMessage, scheme, httpServer.ServerPort, rootPath)
 	}
 
+	// Add route for recent messages of Senzing observers.
+
+	if httpServer.Observations != nil {
+		handle(rootPath+"/observations", serviceObservations, http.HandlerFunc(httpServer.handleFuncForObservations))
+		handle(rootPath+"/events", serviceEvents, http.HandlerFunc(httpServer.handleFuncForEvents))
+	}
+
+	// Add route for querying the audit log.
+
+	if httpServer.Audit != nil {
+		handle(rootPath+"/audit", serviceAudit, http.HandlerFunc(httpServer.handleFuncForAudit))
+	}
+
+	// Add routes for snapshots of the repository.
+
+	if httpServer.Snapshots != nil {
+		handle(rootPath+"/snapshots/", serviceSnapshots, http.StripPrefix(rootPath+"/snapshots", httpServer.getSnapshotsMux()))
+	}
+
+	// Add routes for uploading files of records and loading them.  Uploaded files are removed when the server stops.
+
+	httpServer.uploads = &uploads{entries: map[string]*upload{}}
+	defer httpServer.uploads.removeAll()
+	handle(rootPath+"/upload/", serviceUpload, http.StripPrefix(rootPath+"/upload", httpServer.getUploadMux(ctx)))
+
 	// Add route to template pages.
 
-	rootMux.HandleFunc("/site/", httpServer.handleFuncForSite)
-	userMessage = fmt.Sprintf("%sServing Console at          http://localhost:%d\n", userMessage, httpServer.ServerPort)
+	handle(rootPath+"/component/", serviceConsole, http.HandlerFunc(httpServer.handleFuncForSite))
+	handle(rootPath+"/site/", serviceConsole, http.HandlerFunc(httpServer.handleFuncForSite))
+	userMessage = fmt.Sprintf("%sServing Console at          %s://localhost:%d%s/\n", userMessage, scheme, httpServer.ServerPort, rootPath)
 
 	// Add route for /notebooks.
 
-	rootMux.Handle("/examples/", http.StripPrefix("/examples", http.FileServer(http.Dir("/examples"))))
+	handle(rootPath+"/examples/", serviceConsole, http.StripPrefix(rootPath+"/examples", http.FileServer(http.Dir("/examples"))))
 
 	// Add route to static files.
 
 	rootDir, err := fs.Sub(httpServer.getStatic(), "static/root")
 	if err != nil {
-		panic(err)
+		return err
+	}
+	handle(rootPath+"/", serviceConsole, http.StripPrefix(rootPath+"/", http.FileServer(http.FS(rootDir))))
+
+	// Probe the services behind the console, so its pages show whether each is actually up.
+
+	if len(httpServer.GrpcTarget) > 0 {
+		httpServer.grpcConnection, err = grpc.NewClient(httpServer.GrpcTarget, httpServer.GrpcDialOptions...)
+		if err != nil {
+			return err
+		}
+		defer httpServer.grpcConnection.Close()
+	}
+	healthProber := &healthprobe.BasicHealthProber{
+		Interval: httpServer.HealthProbeInterval,
+		Probes:   httpServer.getHealthProbes(ctx, httpServer.grpcConnection),
 	}
-	rootMux.Handle("/", http.StripPrefix("/", http.FileServer(http.FS(rootDir))))
+	httpServer.healthProber = healthProber
 
 	// Start service.
 
+	if httpServer.GrpcHandler != nil {
+		userMessage = fmt.Sprintf("%sServing gRPC at             %s://localhost:%d\n", userMessage, scheme, httpServer.ServerPort)
+	}
 	listenOnAddress := fmt.Sprintf("%s:%v", httpServer.ServerAddress, httpServer.ServerPort)
 	userMessage = fmt.Sprintf("%sStarting server on interface:port '%s'...\n", userMessage, listenOnAddress)
 	fmt.Println(userMessage)
+
+	// Count, time, and trace requests by the route they match.  With Audit, record data-changing requests.
+	// Share the port with gRPC when GrpcHandler is set.  With Authentication, require
+	// credentials and a role, except for health routes and gRPC calls, which the gRPC server authenticates.
+	// Log every request.
+
+	route := func(r *http.Request) string {
+		_, pattern := rootMux.Handler(r)
+		return pattern
+	}
+	routeRole := func(r *http.Request) (string, string) {
+		pattern := route(r)
+		service := routeServices[pattern]
+		return service, getRole(service, r.Method, strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(pattern, "/")))
+	}
+	handler := httpServer.Tracing.InstrumentHandler(httpServer.Metrics.InstrumentHandler(rootMux, route), route)
+	handler = httpServer.Audit.Handler(handler, func(r *http.Request) string {
+		service, role := routeRole(r)
+		if !isAudited(service, role) {
+			return ""
+		}
+		return service
+	})
+	if httpServer.GrpcHandler != nil {
+		handler = httpServer.multiplexGrpc(handler)
+	}
+	handler = httpServer.Authentication.Handler(handler, func(r *http.Request) string {
+		if httpServer.GrpcHandler != nil && isGrpcRequest(r) {
+			return authentication.RoleNone
+		}
+		_, role := routeRole(r)
+		return role
+	})
+	handler = httpServer.logAccess(handler, func(r *http.Request) string {
+		if httpServer.GrpcHandler != nil && isGrpcRequest(r) {
+			return serviceGrpc
+		}
+		service, isRouted := routeServices[route(r)]
+		if !isRouted {
+			return serviceNone
+		}
+		return service
+	})
 	server := http.Server{
 		ReadHeaderTimeout: httpServer.ReadHeaderTimeout,
 		Addr:              listenOnAddress,
-		Handler:           rootMux,
+		Handler:           handler,
+		ConnContext: func(ctx context.Context, connection net.Conn) context.Context {
+			return context.WithValue(ctx, connectionContextKey, connection)
+		},
 	}
 
-	// Start a web browser.  Unless disabled.
+	// Event streams never finish on their own, so end them when shutting down.
 
-	if !httpServer.TtyOnly {
-		_ = browser.OpenURL(fmt.Sprintf("http://localhost:%d", httpServer.ServerPort))
+	server.RegisterOnShutdown(func() {
+		close(httpServer.eventStreamsDone)
+	})
+
+	// Serve HTTPS when a certificate is configured or requested.
+
+	if httpServer.isTLSEnabled() {
+		server.TLSConfig, err = httpServer.getTLSConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	// gRPC requires HTTP/2, so without TLS accept h2c.
+	// Configuring the HTTP/2 server lets Shutdown tell h2c connections to go away.
+
+	if httpServer.GrpcHandler != nil {
+		if !httpServer.isTLSEnabled() {
+			http2Server := &http2.Server{}
+			err = http2.ConfigureServer(&server, http2Server)
+			if err != nil {
+				return err
+			}
+			server.Handler = h2c.NewHandler(server.Handler, http2Server)
+		}
 	}
 
+	// Bind before opening a browser so failures such as "address already in use" are reported.
+
+	var listener, redirectListener net.Listener
 	if !httpServer.AvoidServing {
-		err = server.ListenAndServe()
+		listener, err = net.Listen("tcp", listenOnAddress)
 		if err != nil {
-			panic(err)
+			return err
+		}
+		if httpServer.isTLSEnabled() && httpServer.HTTPRedirectPort > 0 {
+			redirectListener, err = net.Listen("tcp", fmt.Sprintf("%s:%v", httpServer.ServerAddress, httpServer.HTTPRedirectPort))
+			if err != nil {
+				_ = listener.Close()
+				return err
+			}
+			fmt.Printf("Redirecting HTTP requests on port %d to HTTPS.\n", httpServer.HTTPRedirectPort)
+		}
+	}
+
+	// Start a web browser.  Unless disabled.
+
+	if !httpServer.TtyOnly {
+		_ = browser.OpenURL(fmt.Sprintf("%s://localhost:%d%s/", scheme, httpServer.ServerPort, rootPath))
+	}
+
+	if httpServer.AvoidServing {
+		return err
+	}
+
+	probeCtx, stopProbing := context.WithCancel(ctx)
+	defer stopProbing()
+	go func() {
+		_ = healthProber.Serve(probeCtx)
+	}()
+
+	serveErrors := make(chan error, 2)
+	go func() {
+		if httpServer.isTLSEnabled() {
+			serveErrors <- server.ServeTLS(listener, "", "")
+			return
 		}
+		serveErrors <- server.Serve(listener)
+	}()
+
+	redirectServer := http.Server{
+		ReadHeaderTimeout: httpServer.ReadHeaderTimeout,
+		Handler:           http.HandlerFunc(httpServer.redirectToHTTPS),
+	}
+	if redirectListener != nil {
+		go func() {
+			serveErrors <- redirectServer.Serve(redirectListener)
+		}()
+	}
+
+	select {
+	case err = <-serveErrors:
+		_ = server.Close()
+		_ = redirectServer.Close()
+		return err
+	case <-ctx.Done():
 	}
+
+	_ = redirectServer.Close()
+	err = httpServer.shutdown(ctx, &server)
+	<-serveErrors
 	return err
 }
 
@@ -209,28 +562,172 @@ func (httpServer *BasicHTTPServer) Serve(ctx context.Context) error {
 // Internal methods
 // ----------------------------------------------------------------------------
 
-func (httpServer *BasicHTTPServer) getServerStatus(up bool) string {
-	result := "red"
-	if httpServer.EnableAll {
-		result = "green"
+// Normalized BasePath: "" or a path with a leading, but no trailing, slash.
+func (httpServer *BasicHTTPServer) rootPath() string {
+	return strings.TrimSuffix(path.Join("/", httpServer.BasePath), "/")
+}
+
+// URL path of a route prefix under BasePath.  Example: "/playground/api".
+func (httpServer *BasicHTTPServer) routePath(routePrefix string) string {
+	return path.Join("/", httpServer.rootPath(), routePrefix)
+}
+
+// Python expression that opens a channel to the gRPC server, as shown in console examples.
+func (httpServer *BasicHTTPServer) getGrpcChannel() string {
+	target := fmt.Sprintf("localhost:%d", httpServer.GrpcPort)
+	if httpServer.GrpcHandler != nil {
+		target = fmt.Sprintf("localhost:%d", httpServer.ServerPort)
+	}
+	if !httpServer.GrpcTLS {
+		return fmt.Sprintf("grpc.insecure_channel(%q)", target)
+	}
+	credentialArguments := ""
+	for _, argument := range []struct{ name, filename string }{
+		{name: "root_certificates", filename: httpServer.GrpcTLSCAFile},
+		{name: "private_key", filename: httpServer.GrpcTLSClientKeyFile},
+		{name: "certificate_chain", filename: httpServer.GrpcTLSClientCertFile},
+	} {
+		if len(argument.filename) > 0 {
+			credentialArguments = fmt.Sprintf("%s\n            %s=open(%q, \"rb\").read(),", credentialArguments, argument.name, argument.filename)
+		}
 	}
-	if up {
-		result = "green"
+	if len(credentialArguments) > 0 {
+		credentialArguments += "\n        "
+	}
+	return fmt.Sprintf("grpc.secure_channel(\n        %q,\n        grpc.ssl_channel_credentials(%s),\n    )", target, credentialArguments)
+}
+
+// Database URLs in SenzingSettings.  None if the settings cannot be parsed.
+func (httpServer *BasicHTTPServer) getDatabaseURLs(ctx context.Context) []string {
+	parsedSenzingSettings, err := settingsparser.New(httpServer.SenzingSettings)
+	if err != nil {
+		return nil
+	}
+	result, err := parsedSenzingSettings.GetDatabaseURLs(ctx)
+	if err != nil {
+		return nil
 	}
 	return result
 }
 
-func (httpServer *BasicHTTPServer) getServerURL(up bool, url string) string {
-	result := ""
-	if httpServer.EnableAll {
-		result = url
+// Whether the server is ready, and its overall state, from the states of the health probes.
+// It is ready once every readiness probe is up or degraded.
+func (httpServer *BasicHTTPServer) getReadiness(health []healthprobe.ServiceStatus) (bool, string) {
+	if httpServer.healthProber == nil {
+		return false, StatusStarting
+	}
+	ready := true
+	result := StatusUp
+	for _, status := range health {
+		switch {
+		case readinessProbes[status.Name] && status.State == healthprobe.StateUnknown:
+			ready = false
+			if result != StatusDown {
+				result = StatusStarting
+			}
+		case readinessProbes[status.Name] && status.State == healthprobe.StateDown:
+			ready = false
+			result = StatusDown
+		case status.State != healthprobe.StateUp && result == StatusUp:
+			result = StatusDegraded
+		}
+	}
+	return ready, result
+}
+
+func (httpServer *BasicHTTPServer) getScheme() string {
+	if httpServer.isTLSEnabled() {
+		return "https"
+	}
+	return "http"
+}
+
+// Latest result of each health probe.  Empty until Serve starts probing.
+func (httpServer *BasicHTTPServer) getHealth() []healthprobe.ServiceStatus {
+	if httpServer.healthProber == nil {
+		return nil
 	}
-	if up {
-		result = url
+	result := httpServer.healthProber.Status()
+	for index := range result {
+		result[index].Latency = result[index].Latency.Round(100 * time.Microsecond)
 	}
 	return result
 }
 
+// Probes of the services the enabled routes depend on, run every HealthProbeInterval while serving.
+// Without a gRPC connection, gRPC and the engine are not probed.
+func (httpServer *BasicHTTPServer) getHealthProbes(ctx context.Context, grpcConnection *grpc.ClientConn) []healthprobe.Probe {
+	result := []healthprobe.Probe{}
+	for index, databaseURL := range httpServer.getDatabaseURLs(ctx) {
+		name := serviceDatabase
+		if index > 0 {
+			name = fmt.Sprintf("%s-%d", serviceDatabase, index+1)
+		}
+		result = append(result, healthprobe.Probe{Check: healthprobe.CheckDatabase(databaseURL), Name: name})
+	}
+	if grpcConnection != nil {
+		result = append(result,
+			healthprobe.Probe{Check: healthprobe.CheckGrpcHealth(grpcConnection, ""), Name: serviceGrpc},
+			healthprobe.Probe{Check: healthprobe.CheckSenzingEngine(grpcConnection), Name: serviceSenzingEngine},
+		)
+	}
+	if httpServer.EnableAll || httpServer.EnableSenzingRestAPI || httpServer.EnableEntitySearch {
+		switch {
+		case !httpServer.GoRestAPI:
+			result = append(result, healthprobe.Probe{Check: healthprobe.CheckHTTP(nil, senzingPocServerTarget+"/heartbeat"), Name: serviceSenzingRestAPI})
+		case grpcConnection != nil:
+			result = append(result, healthprobe.Probe{Check: healthprobe.CheckSenzingEngine(grpcConnection), Name: serviceSenzingRestAPI})
+		}
+	}
+	if httpServer.EnableAll || httpServer.EnableJupyterLab {
+		jupyterLabURL := jupyterLabTarget + httpServer.routePath(httpServer.JupyterLabRoutePrefix) + "/api"
+		result = append(result, healthprobe.Probe{Check: healthprobe.CheckHTTP(nil, jupyterLabURL), Name: serviceJupyterLab})
+	}
+	if (httpServer.EnableAll || httpServer.EnableXterm) && len(httpServer.XtermCommand) > 0 {
+		result = append(result, healthprobe.Probe{Check: healthprobe.CheckCommand(httpServer.XtermCommand), Name: serviceXterm})
+	}
+	return result
+}
+
+// Color of a service's status light.  A disabled service is red.  An enabled one
+// takes the color of its health probe's state: "" when it has no probe.
+func (httpServer *BasicHTTPServer) getServerStatus(up bool, state string) string {
+	if !httpServer.EnableAll && !up {
+		return "red"
+	}
+	switch state {
+	case healthprobe.StateDegraded:
+		return "orange"
+	case healthprobe.StateDown:
+		return "red"
+	case healthprobe.StateUnknown:
+		return "gray"
+	}
+	return "green"
+}
+
+// URL of a service, unless it is disabled or its health probe finds it down.
+func (httpServer *BasicHTTPServer) getServerURL(up bool, state string, url string) string {
+	if (!httpServer.EnableAll && !up) || state == healthprobe.StateDown {
+		return ""
+	}
+	return url
+}
+
+// Version of Senzing, as reported by the gRPC server.  Nil if it cannot be reached.
+func (httpServer *BasicHTTPServer) getSenzingVersion(ctx context.Context) json.RawMessage {
+	if httpServer.grpcConnection == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthprobe.DefaultTimeout)
+	defer cancel()
+	response, err := szproduct.NewSzProductClient(httpServer.grpcConnection).GetVersion(ctx, &szproduct.GetVersionRequest{})
+	if err != nil || !json.Valid([]byte(response.GetResult())) {
+		return nil
+	}
+	return json.RawMessage(response.GetResult())
+}
+
 func (httpServer *BasicHTTPServer) getStatic() fs.FS {
 	if httpServer.IsInDevelopment {
 		return os.DirFS("httpserver/")
@@ -238,27 +735,76 @@ func (httpServer *BasicHTTPServer) getStatic() fs.FS {
 	return static
 }
 
+// Load TLSCertFile and TLSKeyFile or, failing a TLSCertFile, generate a self-signed certificate.
+func (httpServer *BasicHTTPServer) getTLSConfig() (*tls.Config, error) {
+	var certificate tls.Certificate
+	var err error
+	if len(httpServer.TLSCertFile) > 0 {
+		certificate, err = tls.LoadX509KeyPair(httpServer.TLSCertFile, httpServer.TLSKeyFile)
+	} else {
+		hostnames := append([]string{"localhost"}, httpServer.XtermAllowedHostnames...)
+		var certificatePEM, privateKeyPEM []byte
+		certificatePEM, privateKeyPEM, err = selfsigned.Generate(hostnames)
+		if err == nil {
+			certificate, err = tls.X509KeyPair(certificatePEM, privateKeyPEM)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	return result, err
+}
+
+// Unary methods of the gRPC services at GrpcTarget are served over the Connect protocol,
+// so Connect needs a gRPC server to bridge to.
+func (httpServer *BasicHTTPServer) isConnectEnabled() bool {
+	return (httpServer.EnableAll || httpServer.EnableConnect) && len(httpServer.GrpcTarget) > 0
+}
+
+func (httpServer *BasicHTTPServer) isTLSEnabled() bool {
+	return len(httpServer.TLSCertFile) > 0 || httpServer.TLSSelfSigned
+}
+
+// Route gRPC calls to GrpcHandler, so they share the HTTP port.
+func (httpServer *BasicHTTPServer) multiplexGrpc(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isGrpcRequest(r) {
+			httpServer.GrpcHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
 func (httpServer *BasicHTTPServer) openAPIFunc(ctx context.Context, openAPISpecification []byte) http.HandlerFunc {
 	_ = ctx
 	_ = openAPISpecification
 	return func(w http.ResponseWriter, r *http.Request) {
 		var bytesBuffer bytes.Buffer
 		bufioWriter := bufio.NewWriter(&bytesBuffer)
-		openAPISpecificationTemplate, err := template.New("OpenApiTemplate").Parse(string(httpServer.OpenAPISpecificationRest))
+		// The specification's server URL assumes the API is served at "/api".
+		openAPISpecification := strings.ReplaceAll(string(httpServer.OpenAPISpecificationRest), "http://{{.RequestHost}}/api", "{{.APIServerURL}}")
+		openAPISpecificationTemplate, err := template.New("OpenApiTemplate").Parse(openAPISpecification)
 		if err != nil {
-			panic(err)
+			httpServer.log(4004, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
 		}
 		templateVariables := TemplateVariables{
-			RequestHost: r.Host,
+			APIServerURL: fmt.Sprintf("%s://%s%s", httpServer.getScheme(), r.Host, httpServer.routePath(httpServer.APIUrlRoutePrefix)),
+			RequestHost:  r.Host,
 		}
 		err = openAPISpecificationTemplate.Execute(bufioWriter, templateVariables)
 		if err != nil {
-			panic(err)
-		}
-		_, err = w.Write(bytesBuffer.Bytes())
-		if err != nil {
-			panic(err)
+			httpServer.log(4004, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
 		}
+		_, _ = w.Write(bytesBuffer.Bytes())
 	}
 }
 func (httpServer *BasicHTTPServer) populateStaticTemplate(responseWriter http.ResponseWriter, request *http.Request, filepath string, templateVariables TemplateVariables) {
@@ -266,81 +812,516 @@ func (httpServer *BasicHTTPServer) populateStaticTemplate(responseWriter http.Re
 	// templateBytes, err := static.ReadFile(filepath)
 	templateBytes, err := fs.ReadFile(httpServer.getStatic(), filepath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		httpServer.log(4001, filepath, err)
 		http.Error(responseWriter, http.StatusText(500), 500)
 		return
 	}
 	templateParsed, err := template.New("HtmlTemplate").Parse(string(templateBytes))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		httpServer.log(4002, filepath, err)
 		http.Error(responseWriter, http.StatusText(500), 500)
 		return
 	}
 	err = templateParsed.Execute(responseWriter, templateVariables)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		httpServer.log(4003, filepath, err)
 		http.Error(responseWriter, http.StatusText(500), 500)
 		return
 	}
 }
 
-// ----------------------------------------------------------------------------
-// Methods for Go-based API server - in development
-// ----------------------------------------------------------------------------
+// Hang up xterm sessions, then drain in-flight requests for up to ShutdownTimeout.
+func (httpServer *BasicHTTPServer) shutdown(ctx context.Context, server *http.Server) error {
+	httpServer.log(2002, httpServer.ShutdownTimeout.String())
+	shutdownCtx := context.WithoutCancel(ctx)
+	if httpServer.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, httpServer.ShutdownTimeout)
+		defer cancel()
+	}
 
-// func (httpServer *HttpServerImpl) getSenzingRestApiGenericMux(ctx context.Context, urlRoutePrefix string) *senzingrestapi.Server {
-// 	service := &senzingrestservice.SenzingRestServiceImpl{
-// 		GrpcDialOptions:                httpServer.GrpcDialOptions,
-// 		GrpcTarget:                     httpServer.GrpcTarget,
-// 		LogLevelName:                   httpServer.LogLevelName,
-// 		ObserverOrigin:                 httpServer.ObserverOrigin,
-// 		Observers:                      httpServer.Observers,
-// 		SenzingEngineConfigurationJson: httpServer.SenzingEngineConfigurationJson,
-// 		SenzingModuleName:              httpServer.SenzingModuleName,
-// 		SenzingVerboseLogging:          httpServer.SenzingVerboseLogging,
-// 		UrlRoutePrefix:                 urlRoutePrefix,
-// 		OpenApiSpecificationSpec:       httpServer.OpenApiSpecificationRest,
-// 	}
-// 	srv, err := senzingrestapi.NewServer(service, httpServer.ServerOptions...)
-// 	if err != nil {
-// 		log.Fatal(err)
-// 	}
-// 	return srv
-// }
-
-// func (httpServer *HttpServerImpl) getSenzingRestApiMux(ctx context.Context) *senzingrestapi.Server {
-// 	return httpServer.getSenzingRestApiGenericMux(ctx, "/api")
-// }
-
-// func (httpServer *HttpServerImpl) getSenzingApiProxyMux(ctx context.Context) *senzingrestapi.Server {
-// 	return httpServer.getSenzingApiGenericMux(ctx, "/entity-search/api")
-// }
+	closedSessions := httpServer.xtermSessions.closeAll()
+	if closedSessions > 0 {
+		httpServer.log(2003, closedSessions)
+	}
+
+	err := server.Shutdown(shutdownCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		httpServer.log(3001, httpServer.ShutdownTimeout.String())
+		_ = server.Close()
+		return ErrShutdownTimeout
+	}
+	return err
+}
+
+// --- Logging ----------------------------------------------------------------
+
+// Get the Logger singleton.
+func (httpServer *BasicHTTPServer) getLogger() logging.Logging {
+	var err error
+	if httpServer.logger == nil {
+		options := []interface{}{
+			logging.OptionCallerSkip{Value: 3},
+			logging.OptionMessageFields{Value: []string{"id", "text", "reason", "errors", "details"}},
+		}
+		if len(httpServer.LogLevelName) > 0 {
+			options = append(options, logging.OptionLogLevel{Value: httpServer.LogLevelName})
+		}
+		httpServer.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return httpServer.logger
+}
+
+// Log message.
+func (httpServer *BasicHTTPServer) log(messageNumber int, details ...interface{}) {
+	httpServer.getLogger().Log(messageNumber, details...)
+}
+
+// Log each request once it is served, at INFO level: what was asked, by whom, which sub-service answered, and how.
+func (httpServer *BasicHTTPServer) logAccess(handler http.Handler, service func(r *http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !httpServer.getLogger().IsInfo() {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		started := time.Now()
+		recorder := &accessRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(recorder, r)
+		httpServer.log(2001, map[string]string{
+			"bytes":                strconv.FormatInt(recorder.bytes, 10),
+			"durationMilliseconds": strconv.FormatFloat(float64(time.Since(started).Microseconds())/1000, 'f', 3, 64),
+			"method":               r.Method,
+			"path":                 r.URL.Path,
+			"remoteAddress":        r.RemoteAddr,
+			"service":              service(r),
+			"status":               strconv.Itoa(recorder.statusCode),
+		})
+	})
+}
+
+// Log a request a reverse proxy could not deliver, and answer it with 502 Bad Gateway.
+func (httpServer *BasicHTTPServer) proxyErrorHandler(target string) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		httpServer.log(4005, r.Method, r.URL.Path, target, err)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+}
+
+// --- accessRecorder ---------------------------------------------------------
+
+func (recorder *accessRecorder) Write(data []byte) (int, error) {
+	count, err := recorder.ResponseWriter.Write(data)
+	recorder.bytes += int64(count)
+	return count, err
+}
+
+func (recorder *accessRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush, so streamed responses, like gRPC's, are not held back.
+func (recorder *accessRecorder) Flush() {
+	if flusher, isFlusher := recorder.ResponseWriter.(http.Flusher); isFlusher {
+		flusher.Flush()
+	}
+}
+
+// Hijack, so websockets, like xterm's, can be upgraded.
+func (recorder *accessRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, isHijacker := recorder.ResponseWriter.(http.Hijacker)
+	if !isHijacker {
+		return nil, nil, http.ErrNotSupported
+	}
+	recorder.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+func (recorder *accessRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
+// --- bufferedResponse -------------------------------------------------------
+
+func (response *bufferedResponse) Header() http.Header {
+	if response.header == nil {
+		response.header = http.Header{}
+	}
+	return response.header
+}
+
+func (response *bufferedResponse) Write(data []byte) (int, error) {
+	if response.statusCode == 0 {
+		response.statusCode = http.StatusOK
+	}
+	return response.body.Write(data)
+}
+
+// Only the first status code counts, as with an http.ResponseWriter.
+func (response *bufferedResponse) WriteHeader(statusCode int) {
+	if response.statusCode == 0 {
+		response.statusCode = statusCode
+	}
+}
+
+// Send the response to w, with the replacements replacer makes in its body.
+func (response *bufferedResponse) sendTo(w http.ResponseWriter, replacer *strings.Replacer) {
+	for key, values := range response.Header() {
+		if key != "Content-Length" {
+			w.Header()[key] = values
+		}
+	}
+	if response.statusCode == 0 {
+		response.statusCode = http.StatusOK
+	}
+	w.WriteHeader(response.statusCode)
+	_, _ = replacer.WriteString(w, response.body.String())
+}
+
+// --- Xterm sessions ---------------------------------------------------------
+
+// Track the connection behind each websocket upgrade so it can be closed on shutdown.
+// Count and audit the sessions.
+func (sessions *xtermSessions) track(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connection, isConnection := r.Context().Value(connectionContextKey).(net.Conn)
+		if isConnection && strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			sessions.mutex.Lock()
+			sessions.connections[connection] = struct{}{}
+			sessions.mutex.Unlock()
+			sessions.metrics.XtermSessionStarted()
+			sessions.audit.RecordRequest(r, audit.ServiceXterm, audit.OperationSessionStart, "")
+			defer func() {
+				sessions.mutex.Lock()
+				delete(sessions.connections, connection)
+				sessions.mutex.Unlock()
+				sessions.metrics.XtermSessionEnded()
+				sessions.audit.RecordRequest(r, audit.ServiceXterm, audit.OperationSessionStop, "")
+			}()
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Close every tracked connection.  The browser sees the terminal disconnect;
+// the spawned shells are hung up when the PTY masters close on process exit.
+func (sessions *xtermSessions) closeAll() int {
+	sessions.mutex.Lock()
+	defer sessions.mutex.Unlock()
+	result := 0
+	for connection := range sessions.connections {
+		if err := connection.Close(); err == nil {
+			result++
+		}
+		delete(sessions.connections, connection)
+	}
+	return result
+}
+
+// --- Uploads ----------------------------------------------------------------
+
+// Load an uploaded file, then summarize how its records resolved, updating its status as it goes.
+func (httpServer *BasicHTTPServer) loadUpload(ctx context.Context, entry upload, factory senzing.SzAbstractFactory) {
+	uploads := httpServer.uploads
+	recordLoader := &loader.BasicLoader{
+		Factory:      factory,
+		FailuresFile: entry.failuresFile,
+		InputFile:    entry.file,
+		Progress: func(result loader.Result) {
+			uploads.update(entry.status.ID, func(status *UploadStatus) {
+				status.Progress = &result
+			})
+		},
+		ProgressInterval: uploadProgressInterval,
+	}
+	_, err := recordLoader.Load(ctx)
+	if err == nil {
+		uploads.update(entry.status.ID, func(status *UploadStatus) {
+			status.State = UploadStateSummarizing
+		})
+		var engine senzing.SzEngine
+		engine, err = factory.CreateEngine(ctx)
+		if err == nil {
+			var summary loader.Summary
+			summary, err = loader.Summarize(ctx, engine, entry.file, entry.failuresFile)
+			uploads.update(entry.status.ID, func(status *UploadStatus) {
+				status.Summary = &summary
+			})
+		}
+	}
+	if err != nil {
+		httpServer.log(4006, entry.status.File, err)
+	}
+	uploads.update(entry.status.ID, func(status *UploadStatus) {
+		status.State = UploadStateDone
+		if err != nil {
+			status.Error = err.Error()
+			status.State = UploadStateFailed
+		}
+	})
+}
+
+func (httpServer *BasicHTTPServer) getUploadMaxCount() int {
+	if httpServer.UploadMaxCount <= 0 {
+		return DefaultUploadMaxCount
+	}
+	return httpServer.UploadMaxCount
+}
+
+func (httpServer *BasicHTTPServer) getUploadMaxSize() int64 {
+	if httpServer.UploadMaxSize <= 0 {
+		return DefaultUploadMaxSize
+	}
+	return httpServer.UploadMaxSize
+}
+
+// Keep an upload, and remove the oldest ones not being loaded, so no more than maxCount are kept.
+func (uploads *uploads) add(entry *upload, maxCount int) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	uploads.entries[entry.status.ID] = entry
+	uploads.ids = append(uploads.ids, entry.status.ID)
+	for index := 0; len(uploads.ids) > maxCount && index < len(uploads.ids); {
+		oldest := uploads.entries[uploads.ids[index]]
+		if oldest == entry || oldest.isBusy() {
+			index++
+			continue
+		}
+		uploads.removeEntry(index)
+	}
+}
+
+func (uploads *uploads) finishLoad() {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	uploads.isLoading = false
+	uploads.loads.Done()
+}
+
+func (uploads *uploads) finishRestore() {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	uploads.isRestoring = false
+}
+
+// A copy of an upload, so its status can be read while it is loading.
+func (uploads *uploads) get(id string) (upload, bool) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	entry, isFound := uploads.entries[id]
+	if !isFound {
+		return upload{}, false
+	}
+	return *entry, true
+}
+
+// The directory uploaded files are kept in, created on first use.
+func (uploads *uploads) getDir() (string, error) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	if len(uploads.dir) == 0 {
+		dir, err := os.MkdirTemp("", "playground-uploads-")
+		if err != nil {
+			return "", err
+		}
+		uploads.dir = dir
+	}
+	return uploads.dir, nil
+}
+
+// Remove an uploaded file.  The status code is http.StatusNoContent if it was removed;
+// otherwise, why not: not found, or a conflict with its load.
+func (uploads *uploads) remove(id string) int {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	entry, isFound := uploads.entries[id]
+	switch {
+	case !isFound:
+		return http.StatusNotFound
+	case entry.isBusy():
+		return http.StatusConflict
+	}
+	uploads.removeEntry(slices.Index(uploads.ids, id))
+	return http.StatusNoContent
+}
+
+// Wait for a load in progress to stop, then remove every uploaded file.
+func (uploads *uploads) removeAll() {
+	uploads.loads.Wait()
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	if len(uploads.dir) > 0 {
+		_ = os.RemoveAll(uploads.dir)
+	}
+	uploads.dir = ""
+	uploads.entries = map[string]*upload{}
+	uploads.ids = nil
+}
+
+// Remove the upload at index of ids, and its files.  The caller holds the mutex.
+func (uploads *uploads) removeEntry(index int) {
+	entry := uploads.entries[uploads.ids[index]]
+	for _, file := range []string{entry.failuresFile, entry.file, entry.original} {
+		if len(file) > 0 {
+			_ = os.Remove(file)
+		}
+	}
+	delete(uploads.entries, entry.status.ID)
+	uploads.ids = slices.Delete(uploads.ids, index, index+1)
+}
+
+// Replace the converted file of an upload, and its mapping and analysis.  The status code is http.StatusOK
+// if it was replaced; otherwise, why not: not found, or a conflict with a load that has started.
+func (uploads *uploads) remap(id string, file string, mapping loader.Mapping, analysis loader.Analysis) (UploadStatus, int) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	entry, isFound := uploads.entries[id]
+	switch {
+	case !isFound:
+		return UploadStatus{}, http.StatusNotFound
+	case entry.status.State != UploadStateUploaded:
+		return entry.status, http.StatusConflict
+	}
+	err := os.Rename(file, entry.file)
+	if err != nil {
+		return entry.status, http.StatusInternalServerError
+	}
+	entry.status.Analysis = analysis
+	entry.status.Mapping = &mapping
+	return entry.status, http.StatusOK
+}
+
+// Mark an uploaded file as loading.  The status code is http.StatusAccepted if it may be loaded;
+// otherwise, why not: not found, or a conflict with a load or a restore that has started.
+func (uploads *uploads) startLoad(id string) (upload, int) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	entry, isFound := uploads.entries[id]
+	switch {
+	case !isFound:
+		return upload{}, http.StatusNotFound
+	case uploads.isLoading || uploads.isRestoring || entry.status.State != UploadStateUploaded:
+		return *entry, http.StatusConflict
+	}
+	uploads.isLoading = true
+	uploads.loads.Add(1)
+	entry.status.State = UploadStateLoading
+	return *entry, http.StatusAccepted
+}
+
+// Mark a restore as started, unless an uploaded file is being loaded or another restore has started.
+// Until finishRestore, no load starts.
+func (uploads *uploads) startRestore() bool {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	if uploads.isLoading || uploads.isRestoring {
+		return false
+	}
+	uploads.isRestoring = true
+	return true
+}
+
+// Whether an upload is being loaded, so its files are in use.
+func (entry *upload) isBusy() bool {
+	return entry.status.State == UploadStateLoading || entry.status.State == UploadStateSummarizing
+}
+
+func (uploads *uploads) update(id string, change func(status *UploadStatus)) {
+	uploads.mutex.Lock()
+	defer uploads.mutex.Unlock()
+	if entry, isFound := uploads.entries[id]; isFound {
+		change(&entry.status)
+	}
+}
 
 // --- http.ServeMux ----------------------------------------------------------
 
 func (httpServer *BasicHTTPServer) getEntitySearchMux(ctx context.Context) *http.ServeMux {
 	service := &entitysearchservice.BasicHTTPService{}
-	return service.Handler(ctx)
+	entitySearchMux := service.Handler(ctx)
+	entitySearchPath := httpServer.routePath(httpServer.EntitySearchRoutePrefix)
+	if entitySearchPath == "/entity-search" {
+		return entitySearchMux
+	}
+
+	// The Entity Search web app is built with "/entity-search" as its base href,
+	// API base path, and virtual path.  Rewrite the files that hold them.
+
+	submux := http.NewServeMux()
+	submux.Handle("/", entitySearchMux)
+	replacer := strings.NewReplacer(`"/entity-search`, `"`+entitySearchPath)
+	rewriteFunc := func(w http.ResponseWriter, r *http.Request) {
+		response := &bufferedResponse{}
+		entitySearchMux.ServeHTTP(response, r)
+		response.sendTo(w, replacer)
+	}
+	submux.HandleFunc("/{$}", rewriteFunc)
+	submux.HandleFunc("/index.html", rewriteFunc)
+	submux.HandleFunc("/config/", rewriteFunc)
+	return submux
 }
 
-func (httpServer *BasicHTTPServer) getSenzingRestAPIMux(ctx context.Context) *http.ServeMux {
-	service := &restapiservicelegacy.RestApiServiceLegacyImpl{
-		JarFile:         "/app/senzing-poc-server.jar",
-		ProxyTemplate:   "http://localhost:8250%s",
-		CustomTransport: http.DefaultTransport,
+// Serve the Senzing REST API from Go, using the gRPC services at GrpcTarget.  Observers are registered with it.
+func (httpServer *BasicHTTPServer) getSenzingRestAPIGenericMux(ctx context.Context, urlRoutePrefix string) (http.Handler, error) {
+	service := &senzingrestservice.BasicSenzingRestService{
+		GrpcDialOptions:          httpServer.GrpcDialOptions,
+		GrpcTarget:               httpServer.GrpcTarget,
+		LogLevelName:             httpServer.LogLevelName,
+		ObserverOrigin:           httpServer.ObserverOrigin,
+		Observers:                httpServer.Observers,
+		OpenAPISpecificationSpec: httpServer.OpenAPISpecificationRest,
+		Port:                     httpServer.ServerPort,
+		SenzingInstanceName:      httpServer.SenzingInstanceName,
+		SenzingVerboseLogging:    httpServer.SenzingVerboseLogging,
+		Settings:                 httpServer.SenzingSettings,
+		URLRoutePrefix:           strings.TrimPrefix(urlRoutePrefix, "/"),
 	}
-	return service.Handler(ctx)
+	err := service.SetLogLevel(ctx, httpServer.LogLevelName)
+	if err != nil {
+		return nil, err
+	}
+	return senzingrestapi.NewServer(service, httpServer.ServerOptions...)
 }
 
-func (httpServer *BasicHTTPServer) getSenzingRestAPIProxyMux(ctx context.Context) *http.ServeMux {
+// Proxy to the Java POC server, which must be started separately.
+func (httpServer *BasicHTTPServer) getSenzingRestAPILegacyMux(ctx context.Context) *http.ServeMux {
 	service := &restapiservicelegacy.RestApiServiceLegacyImpl{
 		JarFile:         "/app/senzing-poc-server.jar",
-		ProxyTemplate:   "http://localhost:8250%s",
-		CustomTransport: http.DefaultTransport,
+		ProxyTemplate:   senzingPocServerTarget + "%s",
+		CustomTransport: httpServer.Tracing.InstrumentTransport(serviceSenzingRestAPI, httpServer.Metrics.InstrumentTransport(serviceSenzingRestAPI, http.DefaultTransport)),
 	}
 	return service.Handler(ctx)
 }
 
+// With GoRestAPI, serve the Senzing REST API from Go; otherwise proxy it to the Java POC server.
+func (httpServer *BasicHTTPServer) getSenzingRestAPIMux(ctx context.Context) (http.Handler, error) {
+	if httpServer.GoRestAPI {
+		return httpServer.getSenzingRestAPIGenericMux(ctx, httpServer.routePath(httpServer.APIUrlRoutePrefix))
+	}
+	return httpServer.getSenzingRestAPILegacyMux(ctx), nil
+}
+
+// The Senzing REST API that Entity Search calls, served as by getSenzingRestAPIMux.
+func (httpServer *BasicHTTPServer) getSenzingRestAPIProxyMux(ctx context.Context) (http.Handler, error) {
+	if httpServer.GoRestAPI {
+		return httpServer.getSenzingRestAPIGenericMux(ctx, httpServer.routePath(httpServer.EntitySearchRoutePrefix)+"/api")
+	}
+	return httpServer.getSenzingRestAPILegacyMux(ctx), nil
+}
+
+// Routes to list the Snapshots of the repository, and to save, delete, and restore them, or the baseline, while serving.
+func (httpServer *BasicHTTPServer) getSnapshotsMux() *http.ServeMux {
+	submux := http.NewServeMux()
+	submux.HandleFunc("GET /{$}", httpServer.handleFuncForSnapshots)
+	submux.HandleFunc("POST /{$}", httpServer.handleFuncForSnapshotCreate)
+	submux.HandleFunc("DELETE /{name}", httpServer.handleFuncForSnapshotDelete)
+	submux.HandleFunc("POST /{name}/restore", httpServer.handleFuncForSnapshotRestore)
+	return submux
+}
+
 func (httpServer *BasicHTTPServer) getSwaggerUIMux(ctx context.Context) *http.ServeMux {
 	swaggerMux := swaggerui.Handler([]byte{}) // OpenAPI specification handled by openApiFunc()
 	swaggerFunc := swaggerMux.ServeHTTP
@@ -350,6 +1331,25 @@ func (httpServer *BasicHTTPServer) getSwaggerUIMux(ctx context.Context) *http.Se
 	return submux
 }
 
+/*
+Routes for files of JSON Lines, CSV, or TSV records, up to UploadMaxSize bytes.  Once uploaded,
+their records are counted, and loaded on request using the gRPC services at GrpcTarget.
+The columns of CSV and TSV files are mapped to Senzing attributes as suggested by their names,
+until another mapping is put.  The latest UploadMaxCount files are kept, until they are deleted
+or the server stops.
+*/
+func (httpServer *BasicHTTPServer) getUploadMux(ctx context.Context) *http.ServeMux {
+	submux := http.NewServeMux()
+	submux.HandleFunc("POST /{$}", httpServer.handleFuncForUpload)
+	submux.HandleFunc("GET /{id}", httpServer.handleFuncForUploadStatus)
+	submux.HandleFunc("DELETE /{id}", httpServer.handleFuncForUploadDelete)
+	submux.HandleFunc("GET /{id}/failures", httpServer.handleFuncForUploadFailures)
+	submux.HandleFunc("POST /{id}/load", httpServer.loadUploadFunc(ctx))
+	submux.HandleFunc("PUT /{id}/mapping", httpServer.handleFuncForUploadMapping)
+	submux.HandleFunc("GET /{id}/progress", httpServer.handleFuncForUploadProgress)
+	return submux
+}
+
 func (httpServer *BasicHTTPServer) getXtermMux(ctx context.Context) *http.ServeMux {
 	xtermService := &xtermservice.XtermServiceImpl{
 		AllowedHostnames:     httpServer.XtermAllowedHostnames,
@@ -358,33 +1358,609 @@ func (httpServer *BasicHTTPServer) getXtermMux(ctx context.Context) *http.ServeM
 		ConnectionErrorLimit: httpServer.XtermConnectionErrorLimit,
 		KeepalivePingTimeout: httpServer.XtermKeepalivePingTimeout,
 		MaxBufferSizeBytes:   httpServer.XtermMaxBufferSizeBytes,
-		UrlRoutePrefix:       httpServer.XtermURLRoutePrefix,
+		UrlRoutePrefix:       strings.TrimPrefix(httpServer.routePath(httpServer.XtermURLRoutePrefix), "/"),
 	}
 	return xtermService.Handler(ctx)
 }
 
 // --- Http Funcs -------------------------------------------------------------
 
+// Liveness: the HTTP server is answering.
+func (httpServer *BasicHTTPServer) handleFuncForHealthz(w http.ResponseWriter, r *http.Request) {
+	_ = r
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Stream the Observations messages that are events, the kept ones first, as server-sent events.
+// Each event is named by its type and its data is the observer message.
+// Query parameter "type", repeated or comma-separated, limits the types streamed.
+func (httpServer *BasicHTTPServer) handleFuncForEvents(w http.ResponseWriter, r *http.Request) {
+	wantedTypes := map[string]bool{}
+	for _, parameter := range r.URL.Query()["type"] {
+		for _, eventType := range strings.Split(parameter, ",") {
+			if !isEventType(eventType) {
+				http.Error(w, fmt.Sprintf("Unknown event type %q.", eventType), http.StatusBadRequest)
+				return
+			}
+			wantedTypes[eventType] = true
+		}
+	}
+	recent, updates, unsubscribe := httpServer.Observations.Subscribe()
+	defer unsubscribe()
+	responseController := http.NewResponseController(w)
+	writeEvent := func(message string) error {
+		eventType := getEventType(message)
+		if len(eventType) == 0 || (len(wantedTypes) > 0 && !wantedTypes[eventType]) {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, message)
+		return err
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	for _, message := range recent {
+		if writeEvent(message) != nil {
+			return
+		}
+	}
+	if responseController.Flush() != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-httpServer.eventStreamsDone:
+			return
+		case message := <-updates:
+			err = writeEvent(message)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err == nil {
+			err = responseController.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Serve the messages kept by Observations, oldest first.
+func (httpServer *BasicHTTPServer) handleFuncForObservations(w http.ResponseWriter, r *http.Request) {
+	_ = r
+	response := ObservationsResponse{
+		Messages: []json.RawMessage{},
+	}
+	for _, message := range httpServer.Observations.Messages() {
+		rawMessage := json.RawMessage(message)
+		if !json.Valid(rawMessage) {
+			rawMessage, _ = json.Marshal(message)
+		}
+		response.Messages = append(response.Messages, rawMessage)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Records of the audit log, newest last, selected by the "user", "service", "operation", "since", and "limit" parameters.
+func (httpServer *BasicHTTPServer) handleFuncForAudit(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := audit.Filter{
+		Limit:     auditDefaultLimit,
+		Operation: query.Get("operation"),
+		Service:   query.Get("service"),
+		User:      query.Get("user"),
+	}
+	var err error
+	filter.Since, err = audit.ParseSince(query.Get("since"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil || filter.Limit < 0 {
+			http.Error(w, fmt.Sprintf("limit %q is not a number of records", limit), http.StatusBadRequest)
+			return
+		}
+	}
+	records, err := httpServer.Audit.Query(filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, AuditResponse{Records: records})
+}
+
+// Save a file of records, posted as the "file" field of a form, and count its records.
+// Files named *.csv or *.tsv are converted to JSON Lines, a line per row.
+func (httpServer *BasicHTTPServer) handleFuncForUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, httpServer.getUploadMaxSize())
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	part, err := reader.NextPart()
+	for err == nil && part.FormName() != "file" {
+		part, err = reader.NextPart()
+	}
+	if err != nil {
+		http.Error(w, "No file uploaded.", http.StatusBadRequest)
+		return
+	}
+	dir, err := httpServer.uploads.getDir()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	id := newUploadID()
+	entry := &upload{
+		failuresFile: filepath.Join(dir, id+".failures.jsonl"),
+		file:         filepath.Join(dir, id+".jsonl"),
+		status: UploadStatus{
+			File:  filepath.Base(part.FileName()),
+			ID:    id,
+			State: UploadStateUploaded,
+		},
+	}
+	comma := loader.CSVComma(part.FileName())
+	if comma == 0 {
+		err = saveUpload(part, entry.file)
+	} else {
+		entry.original = filepath.Join(dir, id+strings.ToLower(filepath.Ext(part.FileName())))
+		err = saveUpload(part, entry.original)
+		if err == nil {
+			entry.status.Header, err = readUploadHeader(entry.original)
+		}
+		if err == nil {
+			mapping := loader.SuggestMapping(entry.status.File, entry.status.Header)
+			entry.status.Mapping = &mapping
+			err = convertUpload(entry.original, entry.file, mapping)
+		}
+	}
+	if err != nil {
+		_ = os.Remove(entry.file)
+		if len(entry.original) > 0 {
+			_ = os.Remove(entry.original)
+		}
+		statusCode := http.StatusBadRequest
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			err = fmt.Errorf("the file is larger than %d bytes", maxBytesError.Limit)
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("Could not read %s: %s", entry.status.File, err), statusCode)
+		return
+	}
+	entry.status.Analysis, err = loader.Analyze(entry.file, uploadSamples)
+	if err != nil {
+		_ = os.Remove(entry.file)
+		if len(entry.original) > 0 {
+			_ = os.Remove(entry.original)
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httpServer.uploads.add(entry, httpServer.getUploadMaxCount())
+	writeJSON(w, http.StatusCreated, entry.status)
+}
+
+// Remove an uploaded file, unless it is being loaded.
+func (httpServer *BasicHTTPServer) handleFuncForUploadDelete(w http.ResponseWriter, r *http.Request) {
+	statusCode := httpServer.uploads.remove(r.PathValue("id"))
+	if statusCode != http.StatusNoContent {
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+	w.WriteHeader(statusCode)
+}
+
+// Serve the failures file of an uploaded file that has been loaded, as JSON Lines.
+func (httpServer *BasicHTTPServer) handleFuncForUploadFailures(w http.ResponseWriter, r *http.Request) {
+	entry, isFound := httpServer.uploads.get(r.PathValue("id"))
+	if !isFound || entry.status.Progress == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", strings.TrimSuffix(entry.status.File, filepath.Ext(entry.status.File))+"-failures.jsonl"))
+	w.Header().Set("Content-Type", "application/jsonl")
+	http.ServeFile(w, r, entry.failuresFile)
+}
+
+// Convert an uploaded CSV or TSV file again, with the Mapping in the request body, and count its records.
+func (httpServer *BasicHTTPServer) handleFuncForUploadMapping(w http.ResponseWriter, r *http.Request) {
+	entry, isFound := httpServer.uploads.get(r.PathValue("id"))
+	if !isFound {
+		http.NotFound(w, r)
+		return
+	}
+	if len(entry.original) == 0 {
+		http.Error(w, "Only CSV and TSV files have a mapping.", http.StatusBadRequest)
+		return
+	}
+	mapping := loader.Mapping{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, uploadMappingMaxSize)).Decode(&mapping)
+	if err == nil {
+		err = mapping.Validate()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Convert to a new file, replacing the old one only if the upload has not started loading meanwhile.
+
+	converted, err := os.CreateTemp(filepath.Dir(entry.file), entry.status.ID+"-*.jsonl")
+	if err == nil {
+		err = converted.Close()
+	}
+	if err == nil {
+		err = convertUpload(entry.original, converted.Name(), mapping)
+	}
+	var analysis loader.Analysis
+	if err == nil {
+		analysis, err = loader.Analyze(converted.Name(), uploadSamples)
+	}
+	if err != nil {
+		if converted != nil {
+			_ = os.Remove(converted.Name())
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	status, statusCode := httpServer.uploads.remap(entry.status.ID, converted.Name(), mapping, analysis)
+	if statusCode != http.StatusOK {
+		_ = os.Remove(converted.Name())
+		http.Error(w, http.StatusText(statusCode), statusCode)
+		return
+	}
+	writeJSON(w, statusCode, status)
+}
+
+// Stream the UploadStatus of an uploaded file, as server-sent "progress" events, until it is loaded or fails.
+func (httpServer *BasicHTTPServer) handleFuncForUploadProgress(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, isFound := httpServer.uploads.get(id); !isFound {
+		http.NotFound(w, r)
+		return
+	}
+	responseController := http.NewResponseController(w)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	ticker := time.NewTicker(uploadProgressInterval)
+	defer ticker.Stop()
+	for {
+		entry, _ := httpServer.uploads.get(id)
+		status, err := json.Marshal(entry.status)
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, "event: progress\ndata: %s\n\n", status)
+		if err == nil {
+			err = responseController.Flush()
+		}
+		if err != nil || entry.status.State == UploadStateDone || entry.status.State == UploadStateFailed {
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-httpServer.eventStreamsDone:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// The UploadStatus of an uploaded file.
+func (httpServer *BasicHTTPServer) handleFuncForUploadStatus(w http.ResponseWriter, r *http.Request) {
+	entry, isFound := httpServer.uploads.get(r.PathValue("id"))
+	if !isFound {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, entry.status)
+}
+
+// Start loading an uploaded file, unless another is being loaded.  Loading stops when ctx is cancelled.
+func (httpServer *BasicHTTPServer) loadUploadFunc(ctx context.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if httpServer.grpcConnection == nil {
+			http.Error(w, "Loading needs the gRPC services, which are not available.", http.StatusServiceUnavailable)
+			return
+		}
+		factory, err := szfactorycreator.CreateGrpcAbstractFactory(httpServer.grpcConnection)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		entry, statusCode := httpServer.uploads.startLoad(r.PathValue("id"))
+		if statusCode != http.StatusAccepted {
+			http.Error(w, http.StatusText(statusCode), statusCode)
+			return
+		}
+		go func() {
+			defer httpServer.uploads.finishLoad()
+			httpServer.loadUpload(ctx, entry, factory)
+		}()
+		writeJSON(w, statusCode, entry.status)
+	}
+}
+
+// Snapshots of the repository, oldest first.
+func (httpServer *BasicHTTPServer) handleFuncForSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := httpServer.Snapshots.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, SnapshotsResponse{Snapshots: snapshots})
+}
+
+// Save a snapshot of the repository, named by the SnapshotRequest in the request body.
+func (httpServer *BasicHTTPServer) handleFuncForSnapshotCreate(w http.ResponseWriter, r *http.Request) {
+	request := SnapshotRequest{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, snapshotRequestMaxSize)).Decode(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	created, err := httpServer.Snapshots.Create(r.Context(), request.Name)
+	if err != nil {
+		http.Error(w, err.Error(), getSnapshotStatusCode(err))
+		return
+	}
+	httpServer.log(2004, created.Name)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (httpServer *BasicHTTPServer) handleFuncForSnapshotDelete(w http.ResponseWriter, r *http.Request) {
+	err := httpServer.Snapshots.Delete(r.Context(), r.PathValue("name"))
+	if err != nil {
+		http.Error(w, err.Error(), getSnapshotStatusCode(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Restore the repository from a snapshot, or from the baseline, unless an uploaded file is being loaded.
+func (httpServer *BasicHTTPServer) handleFuncForSnapshotRestore(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !httpServer.uploads.startRestore() {
+		http.Error(w, "A file is being loaded, or a snapshot restored.  Restore once it is done.", http.StatusConflict)
+		return
+	}
+	defer httpServer.uploads.finishRestore()
+	err := httpServer.Snapshots.Restore(r.Context(), name)
+	if err != nil {
+		statusCode := getSnapshotStatusCode(err)
+		if statusCode == http.StatusInternalServerError {
+			httpServer.log(4007, name, err)
+		}
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+	httpServer.log(2005, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Readiness: 200 once the gRPC server, the Senzing engine, and the database are up; 503 otherwise.
+func (httpServer *BasicHTTPServer) handleFuncForReadyz(w http.ResponseWriter, r *http.Request) {
+	_ = r
+	ready, status := httpServer.getReadiness(httpServer.getHealth())
+	statusCode := http.StatusOK
+	if !ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+	writeJSON(w, statusCode, ReadinessResponse{Ready: ready, Status: status})
+}
+
+// Detailed status: every health probe, supervised program, and version.  Always 200.
+func (httpServer *BasicHTTPServer) handleFuncForStatus(w http.ResponseWriter, r *http.Request) {
+	health := httpServer.getHealth()
+	response := StatusResponse{
+		Programs: []supervisor.ProgramStatus{},
+		Services: []ServiceHealth{},
+		Version: VersionInfo{
+			Playground: httpServer.Version,
+			Senzing:    httpServer.getSenzingVersion(r.Context()),
+		},
+	}
+	response.Ready, response.Status = httpServer.getReadiness(health)
+	for _, status := range health {
+		serviceHealth := ServiceHealth{
+			LastError:           status.LastError,
+			LatencyMilliseconds: float64(status.Latency) / float64(time.Millisecond),
+			Name:                status.Name,
+			State:               status.State,
+		}
+		if !status.Checked.IsZero() {
+			serviceHealth.Checked = &status.Checked
+		}
+		if !status.LastErrorAt.IsZero() {
+			serviceHealth.LastErrorAt = &status.LastErrorAt
+		}
+		response.Services = append(response.Services, serviceHealth)
+	}
+	if httpServer.Supervisor != nil {
+		response.Programs = append(response.Programs, httpServer.Supervisor.Status()...)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
 func (httpServer *BasicHTTPServer) handleFuncForSite(w http.ResponseWriter, r *http.Request) {
+	serviceURL := func(routePrefix string) string {
+		return fmt.Sprintf("%s://%s%s", httpServer.getScheme(), r.Host, httpServer.routePath(routePrefix))
+	}
+	connectURL := ""
+	if httpServer.isConnectEnabled() {
+		connectURL = serviceURL(httpServer.ConnectRoutePrefix)
+	}
+	var programs []supervisor.ProgramStatus
+	if httpServer.Supervisor != nil {
+		programs = httpServer.Supervisor.Status()
+	}
+	health := httpServer.getHealth()
+	states := map[string]string{}
+	for _, status := range health {
+		states[status.Name] = status.State
+	}
 	templateVariables := TemplateVariables{
-		APIServerStatus:    httpServer.getServerStatus(httpServer.EnableSenzingRestAPI),
-		APIServerURL:       httpServer.getServerURL(httpServer.EnableSenzingRestAPI, fmt.Sprintf("http://%s/api", r.Host)),
+		APIServerStatus:    httpServer.getServerStatus(httpServer.EnableSenzingRestAPI, states[serviceSenzingRestAPI]),
+		APIServerURL:       httpServer.getServerURL(httpServer.EnableSenzingRestAPI, states[serviceSenzingRestAPI], serviceURL(httpServer.APIUrlRoutePrefix)),
+		Attributes:         loader.Attributes,
 		BasicHTTPServer:    *httpServer,
-		EntitySearchStatus: httpServer.getServerStatus(httpServer.EnableEntitySearch),
-		EntitySearchURL:    httpServer.getServerURL(httpServer.EnableEntitySearch, fmt.Sprintf("http://%s/entity-search", r.Host)),
+		ConnectURL:         connectURL,
+		EntitySearchStatus: httpServer.getServerStatus(httpServer.EnableEntitySearch, states[serviceSenzingRestAPI]),
+		EntitySearchURL:    httpServer.getServerURL(httpServer.EnableEntitySearch, states[serviceSenzingRestAPI], serviceURL(httpServer.EntitySearchRoutePrefix)),
+		GrpcChannel:        httpServer.getGrpcChannel(),
+		Health:             health,
 		HTMLTitle:          "Senzing Quickstart",
-		JupyterLabStatus:   httpServer.getServerStatus(httpServer.EnableJupyterLab),
-		JupyterLabURL:      httpServer.getServerURL(httpServer.EnableJupyterLab, fmt.Sprintf("http://%s/jupyter", r.Host)),
-		SwaggerStatus:      httpServer.getServerStatus(httpServer.EnableSwaggerUI),
-		SwaggerURL:         httpServer.getServerURL(httpServer.EnableSwaggerUI, fmt.Sprintf("http://%s/swagger", r.Host)),
-		XtermStatus:        httpServer.getServerStatus(httpServer.EnableXterm),
-		XtermURL:           httpServer.getServerURL(httpServer.EnableXterm, fmt.Sprintf("http://%s/xterm", r.Host)),
+		JupyterLabStatus:   httpServer.getServerStatus(httpServer.EnableJupyterLab, states[serviceJupyterLab]),
+		JupyterLabURL:      httpServer.getServerURL(httpServer.EnableJupyterLab, states[serviceJupyterLab], serviceURL(httpServer.JupyterLabRoutePrefix)),
+		Programs:           programs,
+		RootPath:           httpServer.rootPath(),
+		SwaggerStatus:      httpServer.getServerStatus(httpServer.EnableSwaggerUI, ""),
+		SwaggerURL:         httpServer.getServerURL(httpServer.EnableSwaggerUI, "", serviceURL(httpServer.SwaggerURLRoutePrefix)),
+		XtermStatus:        httpServer.getServerStatus(httpServer.EnableXterm, states[serviceXterm]),
+		XtermURL:           httpServer.getServerURL(httpServer.EnableXterm, states[serviceXterm], serviceURL(httpServer.XtermURLRoutePrefix)),
+	}
+	if httpServer.Authentication != nil && !authentication.IdentityFromContext(r.Context()).HasRole(authentication.RoleAdmin) {
+		templateVariables.JupyterLabStatus, templateVariables.JupyterLabURL = "red", ""
+		templateVariables.XtermStatus, templateVariables.XtermURL = "red", ""
 	}
 	w.Header().Set("Content-Type", "text/html")
-	filePath := fmt.Sprintf("static/templates%s", r.RequestURI)
+	filePath := fmt.Sprintf("static/templates%s", strings.TrimPrefix(r.URL.Path, httpServer.rootPath()))
 	httpServer.populateStaticTemplate(w, r, filePath, templateVariables)
 }
 
+// Permanently redirect a plain HTTP request, on HTTPRedirectPort, to the same URL on the HTTPS port.
+func (httpServer *BasicHTTPServer) redirectToHTTPS(w http.ResponseWriter, r *http.Request) {
+	hostname := r.Host
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		hostname = host
+	}
+	target := url.URL{
+		Scheme:   "https",
+		Host:     net.JoinHostPort(strings.Trim(hostname, "[]"), strconv.Itoa(httpServer.ServerPort)),
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+	}
+	http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+}
+
+// isGrpcRequest reports whether a request is a gRPC call: HTTP/2 with an application/grpc content type.
+func isGrpcRequest(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.ProtoMajor == 2 && (contentType == "application/grpc" || strings.HasPrefix(contentType, "application/grpc+"))
+}
+
+/*
+getRole returns the role a request to a sub-service requires.  The path is relative to the sub-service's route.
+The health probes need no credentials.  Reading needs RoleViewer; changing data through the Senzing REST API
+or uploads, RoleLoader; changing snapshots, and using xterm, JupyterLab, and the audit log, RoleAdmin.
+Connect calls need what grpcserver.MethodRole requires.
+*/
+func getRole(service string, method string, path string) string {
+	switch service {
+	case serviceHealth:
+		return authentication.RoleNone
+	case serviceConnect:
+		return grpcserver.MethodRole(path)
+	case serviceAudit, serviceJupyterLab, serviceXterm:
+		return authentication.RoleAdmin
+	case serviceSenzingRestAPI:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return authentication.RoleViewer
+		}
+		if restAPIQueryPaths[path] {
+			return authentication.RoleViewer
+		}
+		return authentication.RoleLoader
+	case serviceSnapshots:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return authentication.RoleViewer
+		}
+		return authentication.RoleAdmin
+	case serviceUpload:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return authentication.RoleViewer
+		}
+		return authentication.RoleLoader
+	default:
+		return authentication.RoleViewer
+	}
+}
+
+// isAudited reports whether a request to a sub-service, requiring a role, changes data.
+// Requests for the audit log, JupyterLab, and xterm need RoleAdmin but are not audited;
+// xterm sessions are audited as they start and stop.
+func isAudited(service string, role string) bool {
+	switch service {
+	case serviceAudit, serviceJupyterLab, serviceXterm:
+		return false
+	default:
+		return role == authentication.RoleLoader || role == authentication.RoleAdmin
+	}
+}
+
+// getEventType returns the type of event an observer message reports, or "" if it is not an event.
+func getEventType(message string) string {
+	details := map[string]string{}
+	if json.Unmarshal([]byte(message), &details) != nil {
+		return ""
+	}
+	if _, isError := details["error"]; isError {
+		return EventError
+	}
+	return eventTypes[observedCall{messageID: details["messageId"], subjectID: details["subjectId"]}]
+}
+
+// isEventType reports whether a name is one of the event types streamed at "/events".
+func isEventType(name string) bool {
+	switch name {
+	case EventAddRecord, EventConfigChange, EventDelete, EventError:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeJSON writes a value as an uncached JSON response.
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// getSnapshotStatusCode returns the HTTP status code of an error from snapshot.BasicSnapshots.
+func getSnapshotStatusCode(err error) int {
+	switch {
+	case errors.Is(err, snapshot.ErrInvalidName):
+		return http.StatusBadRequest
+	case errors.Is(err, snapshot.ErrNotFound), errors.Is(err, snapshot.ErrNoBaseline):
+		return http.StatusNotFound
+	case errors.Is(err, snapshot.ErrExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // newReverseProxy takes target host and creates a reverse proxy
 func newReverseProxy(targetHost string) (*httputil.ReverseProxy, error) {
 	url, err := url.Parse(targetHost)
@@ -400,3 +1976,45 @@ func reverseProxyRequestHandler(proxy *httputil.ReverseProxy) func(http.Response
 		proxy.ServeHTTP(w, r)
 	}
 }
+
+// convertUpload writes the rows of an uploaded CSV or TSV file to a file of JSON Lines, as mapping says.
+func convertUpload(original string, destination string, mapping loader.Mapping) error {
+	input, err := os.Open(original)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	output, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = loader.ConvertCSV(input, output, loader.CSVComma(original), &mapping)
+	return errors.Join(err, output.Close())
+}
+
+// newUploadID returns a random identifier for an uploaded file.
+func newUploadID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// readUploadHeader returns the column names in the header row of an uploaded CSV or TSV file.
+func readUploadHeader(original string) ([]string, error) {
+	input, err := os.Open(original)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	return loader.ReadCSVHeader(input, loader.CSVComma(original))
+}
+
+// saveUpload writes an uploaded file.
+func saveUpload(input io.Reader, destination string) error {
+	output, err := os.OpenFile(destination, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(output, input)
+	return errors.Join(err, output.Close())
+}
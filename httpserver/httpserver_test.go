@@ -1,18 +1,44 @@
 package httpserver
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/senzing-garage/go-helpers/settings"
+	"github.com/senzing-garage/go-logging/logging"
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/go-rest-api-service/senzingrestservice"
+	"github.com/senzing-garage/playground/audit"
+	"github.com/senzing-garage/playground/authentication"
+	"github.com/senzing-garage/playground/healthprobe"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/senzing-garage/playground/metrics"
+	"github.com/senzing-garage/playground/observing"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/senzing-garage/playground/supervisor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 // ----------------------------------------------------------------------------
@@ -27,25 +53,366 @@ func TestBasicHTTPServer_Serve(test *testing.T) {
 	require.NoError(test, err)
 }
 
+func TestBasicHTTPServer_Serve_cancel(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.ServerAddress = "127.0.0.1"
+	time.AfterFunc(100*time.Millisecond, cancel)
+	err := httpServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestBasicHTTPServer_Serve_portInUse(test *testing.T) {
+	ctx := context.TODO()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	defer listener.Close()
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = listener.Addr().(*net.TCPAddr).Port
+	err = httpServer.Serve(ctx)
+	require.Error(test, err)
+}
+
+func TestBasicHTTPServer_Serve_grpcHandler(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(test, listener.Close())
+	grpcServer := grpc.NewServer()
+	reflection.Register(grpcServer)
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.GrpcHandler = grpcServer
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = port
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.Serve(ctx)
+	}()
+
+	// gRPC over h2c.
+
+	grpcConnection, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(test, err)
+	defer grpcConnection.Close()
+	reflectionClient := grpc_reflection_v1.NewServerReflectionClient(grpcConnection)
+	require.Eventually(test, func() bool {
+		stream, err := reflectionClient.ServerReflectionInfo(ctx)
+		if err != nil {
+			return false
+		}
+		err = stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+			MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+		})
+		if err != nil {
+			return false
+		}
+		response, err := stream.Recv()
+		return err == nil && len(response.GetListServicesResponse().GetService()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// HTTP/1.1 on the same port.
+
+	response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/site/home.html", port))
+	require.NoError(test, err)
+	require.NoError(test, response.Body.Close())
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicHTTPServer_Serve_metrics(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(test, listener.Close())
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.Metrics = &metrics.BasicMetrics{}
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = port
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.Serve(ctx)
+	}()
+
+	require.Eventually(test, func() bool {
+		response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/site/home.html", port))
+		if err != nil {
+			return false
+		}
+		_ = response.Body.Close()
+		return response.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", port))
+	require.NoError(test, err)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(test, err)
+	require.NoError(test, response.Body.Close())
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Contains(test, string(body), `playground_http_requests_total{code="200",method="GET",route="/site/"} 1`)
+
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicHTTPServer_Serve_authentication(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(test, listener.Close())
+	tokensFile := filepath.Join(test.TempDir(), "tokens")
+	require.NoError(test, os.WriteFile(tokensFile, []byte("ci:0123456789abcdef\nroot:fedcba9876543210\n"), 0o600))
+	tokensAuthenticator, err := authentication.NewTokensAuthenticator(tokensFile)
+	require.NoError(test, err)
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.Authentication = &authentication.BasicAuthentication{
+		Authenticators: []authentication.Authenticator{tokensAuthenticator},
+		Roles:          map[string]string{"root": authentication.RoleAdmin},
+	}
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = port
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.Serve(ctx)
+	}()
+
+	require.Eventually(test, func() bool {
+		response, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/healthz", port))
+		if err != nil {
+			return false
+		}
+		_ = response.Body.Close()
+		return response.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	getStatusCode := func(path string, authorization string) int {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d%s", port, path), nil)
+		require.NoError(test, err)
+		if len(authorization) > 0 {
+			request.Header.Set("Authorization", authorization)
+		}
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(test, err)
+		require.NoError(test, response.Body.Close())
+		return response.StatusCode
+	}
+	assert.Equal(test, http.StatusOK, getStatusCode("/readyz", ""))
+	assert.Equal(test, http.StatusUnauthorized, getStatusCode("/status", ""))
+	assert.Equal(test, http.StatusOK, getStatusCode("/status", "Bearer 0123456789abcdef"))
+	assert.Equal(test, http.StatusUnauthorized, getStatusCode("/site/home.html", ""))
+	assert.Equal(test, http.StatusUnauthorized, getStatusCode("/swagger/", "Bearer 0000000000000000"))
+	assert.Equal(test, http.StatusOK, getStatusCode("/site/home.html", "Bearer 0123456789abcdef"))
+	assert.Equal(test, http.StatusForbidden, getStatusCode("/xterm/", "Bearer 0123456789abcdef"), "ci is a viewer")
+	assert.NotEqual(test, http.StatusForbidden, getStatusCode("/xterm/", "Bearer fedcba9876543210"))
+
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicHTTPServer_Serve_connect(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(test, listener.Close())
+	grpcListener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(grpcListener)
+	}()
+	defer grpcServer.Stop()
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.BasePath = "/playground"
+	httpServer.ConnectRoutePrefix = "connect"
+	httpServer.GrpcDialOptions = []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return grpcListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	httpServer.GrpcTarget = "passthrough:///in-process"
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = port
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.Serve(ctx)
+	}()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/playground/connect/grpc.health.v1.Health/Check", port)
+	var body []byte
+	require.Eventually(test, func() bool {
+		response, err := http.Post(url, "application/json", strings.NewReader(`{}`))
+		if err != nil {
+			return false
+		}
+		defer response.Body.Close()
+		body, err = io.ReadAll(response.Body)
+		return err == nil && response.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.JSONEq(test, `{"status": "SERVING"}`, string(body))
+
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicHTTPServer_Serve_tls(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(test, listener.Close())
+	httpServer := getTestObject(ctx, test)
+	httpServer.AvoidServing = false
+	httpServer.ServerAddress = "127.0.0.1"
+	httpServer.ServerPort = port
+	httpServer.TLSSelfSigned = true
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.Serve(ctx)
+	}()
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, //nolint:gosec
+	}
+	require.Eventually(test, func() bool {
+		response, err := client.Get(fmt.Sprintf("https://127.0.0.1:%d/site/extras.html", port))
+		if err != nil {
+			return false
+		}
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		return err == nil && strings.Contains(string(body), fmt.Sprintf("https://127.0.0.1:%d/swagger", port))
+	}, 5*time.Second, 50*time.Millisecond)
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
 // ----------------------------------------------------------------------------
 // Test private functions
 // ----------------------------------------------------------------------------
 
+func TestBasicHTTPServer_getGrpcChannel(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	assert.Equal(test, `grpc.insecure_channel("localhost:8261")`, httpServer.getGrpcChannel())
+	httpServer.GrpcHandler = http.NotFoundHandler()
+	httpServer.ServerPort = 8260
+	assert.Equal(test, `grpc.insecure_channel("localhost:8260")`, httpServer.getGrpcChannel())
+	httpServer.GrpcHandler = nil
+	httpServer.GrpcTLS = true
+	assert.Equal(test, "grpc.secure_channel(\n        \"localhost:8261\",\n        grpc.ssl_channel_credentials(),\n    )", httpServer.getGrpcChannel())
+	httpServer.GrpcTLSCAFile = "/certs/ca.pem"
+	httpServer.GrpcTLSClientCertFile = "/certs/client.pem"
+	httpServer.GrpcTLSClientKeyFile = "/certs/client-key.pem"
+	actual := httpServer.getGrpcChannel()
+	assert.Contains(test, actual, `root_certificates=open("/certs/ca.pem", "rb").read(),`)
+	assert.Contains(test, actual, `private_key=open("/certs/client-key.pem", "rb").read(),`)
+	assert.Contains(test, actual, `certificate_chain=open("/certs/client.pem", "rb").read(),`)
+}
+
+func TestBasicHTTPServer_getReadiness(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	ready, status := httpServer.getReadiness(nil)
+	assert.False(test, ready)
+	assert.Equal(test, StatusStarting, status)
+
+	httpServer.healthProber = &testHealthProber{}
+	testCases := []struct {
+		name          string
+		states        map[string]string
+		expectedReady bool
+		expected      string
+	}{
+		{name: "no probes", states: map[string]string{}, expectedReady: true, expected: StatusUp},
+		{name: "up", states: map[string]string{"grpc": healthprobe.StateUp, "xterm": healthprobe.StateUp}, expectedReady: true, expected: StatusUp},
+		{name: "optional down", states: map[string]string{"grpc": healthprobe.StateUp, "xterm": healthprobe.StateDown}, expectedReady: true, expected: StatusDegraded},
+		{name: "required degraded", states: map[string]string{"grpc": healthprobe.StateDegraded}, expectedReady: true, expected: StatusDegraded},
+		{name: "required unknown", states: map[string]string{"grpc": healthprobe.StateUnknown, "database": healthprobe.StateUp}, expectedReady: false, expected: StatusStarting},
+		{name: "required down", states: map[string]string{"grpc": healthprobe.StateUnknown, "database": healthprobe.StateDown}, expectedReady: false, expected: StatusDown},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			health := []healthprobe.ServiceStatus{}
+			for name, state := range testCase.states {
+				health = append(health, healthprobe.ServiceStatus{Name: name, State: state})
+			}
+			ready, status := httpServer.getReadiness(health)
+			assert.Equal(test, testCase.expectedReady, ready)
+			assert.Equal(test, testCase.expected, status)
+		})
+	}
+}
+
 func TestBasicHTTPServer_getServerStatus(test *testing.T) {
 	_ = test
 	ctx := context.TODO()
 	httpServer := getTestObject(ctx, test)
-	actual := httpServer.getServerStatus(true)
+	actual := httpServer.getServerStatus(true, "")
 	assert.Equal(test, "green", actual)
 }
 
+func TestBasicHTTPServer_getServerStatus_probed(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.EnableAll = false
+	testCases := []struct {
+		up       bool
+		state    string
+		expected string
+	}{
+		{up: false, state: healthprobe.StateUp, expected: "red"},
+		{up: true, state: healthprobe.StateUp, expected: "green"},
+		{up: true, state: healthprobe.StateDegraded, expected: "orange"},
+		{up: true, state: healthprobe.StateDown, expected: "red"},
+		{up: true, state: healthprobe.StateUnknown, expected: "gray"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(test, testCase.expected, httpServer.getServerStatus(testCase.up, testCase.state), testCase.state)
+	}
+}
+
 func TestBasicHTTPServer_getServerURL(test *testing.T) {
 	_ = test
 	ctx := context.TODO()
 	expected := "http://expected"
 	httpServer := getTestObject(ctx, test)
-	actual := httpServer.getServerURL(true, expected)
+	actual := httpServer.getServerURL(true, "", expected)
 	assert.Equal(test, expected, actual)
+	assert.Empty(test, httpServer.getServerURL(true, healthprobe.StateDown, expected))
+}
+
+func TestBasicHTTPServer_getHealthProbes(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.XtermCommand = "sh"
+	probeNames := func(probes []healthprobe.Probe) []string {
+		result := []string{}
+		for _, probe := range probes {
+			result = append(result, probe.Name)
+		}
+		return result
+	}
+	assert.Equal(test, []string{"database", "senzing-rest-api", "jupyter-lab", "xterm"}, probeNames(httpServer.getHealthProbes(ctx, nil)))
+	grpcConnection, err := grpc.NewClient("passthrough:///unused", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(test, err)
+	defer grpcConnection.Close()
+	httpServer.GoRestAPI = true
+	assert.Equal(test, []string{"database", "grpc", "senzing-engine", "senzing-rest-api", "jupyter-lab", "xterm"}, probeNames(httpServer.getHealthProbes(ctx, grpcConnection)))
 }
 
 func TestBasicHTTPServer_openAPIFunc(test *testing.T) {
@@ -67,6 +434,470 @@ func TestBasicHTTPServer_populateStaticTemplate(test *testing.T) {
 	httpServer.populateStaticTemplate(response, request, "/", TemplateVariables{})
 }
 
+func TestBasicHTTPServer_getEntitySearchMux_basePath(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.BasePath = "/playground/"
+	entitySearchMux := httpServer.getEntitySearchMux(ctx)
+	request := httptest.NewRequest(http.MethodGet, "/config/api", nil)
+	response := httptest.NewRecorder()
+	entitySearchMux.ServeHTTP(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `"/playground/entity-search/api"`)
+}
+
+func TestBasicHTTPServer_openAPIFunc_basePath(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.BasePath = "playground"
+	httpServer.APIUrlRoutePrefix = "senzing/api"
+	openAPIFunction := httpServer.openAPIFunc(ctx, httpServer.OpenAPISpecificationRest)
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	response := httptest.NewRecorder()
+	openAPIFunction(response, request)
+	assert.Contains(test, response.Body.String(), `"http://example.com/playground/senzing/api"`)
+}
+
+func TestBasicHTTPServer_getSenzingRestAPIMux_goRestAPI(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.GoRestAPI = true
+	senzingAPIMux, err := httpServer.getSenzingRestAPIMux(ctx)
+	require.NoError(test, err)
+	request := httptest.NewRequest(http.MethodGet, "/specifications/open-api", nil)
+	response := httptest.NewRecorder()
+	senzingAPIMux.ServeHTTP(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Equal(test, senzingrestservice.OpenAPISpecificationJSON, response.Body.Bytes())
+}
+
+func TestBasicHTTPServer_getTLSConfig_badCertFile(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.TLSCertFile = "/tmp/no/such/certificate.pem"
+	httpServer.TLSKeyFile = "/tmp/no/such/key.pem"
+	_, err := httpServer.getTLSConfig()
+	require.Error(test, err)
+}
+
+func TestBasicHTTPServer_redirectToHTTPS(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.ServerPort = 8443
+	request := httptest.NewRequest(http.MethodGet, "http://example.com:8080/site/home.html?a=b", nil)
+	response := httptest.NewRecorder()
+	httpServer.redirectToHTTPS(response, request)
+	assert.Equal(test, http.StatusPermanentRedirect, response.Code)
+	assert.Equal(test, "https://example.com:8443/site/home.html?a=b", response.Header().Get("Location"))
+}
+
+func TestBasicHTTPServer_routePath(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	testCases := []struct {
+		basePath         string
+		expectedRootPath string
+		expectedAPIPath  string
+	}{
+		{basePath: "", expectedRootPath: "", expectedAPIPath: "/api"},
+		{basePath: "/", expectedRootPath: "", expectedAPIPath: "/api"},
+		{basePath: "playground", expectedRootPath: "/playground", expectedAPIPath: "/playground/api"},
+		{basePath: "/playground/", expectedRootPath: "/playground", expectedAPIPath: "/playground/api"},
+	}
+	for _, testCase := range testCases {
+		httpServer.BasePath = testCase.basePath
+		assert.Equal(test, testCase.expectedRootPath, httpServer.rootPath())
+		assert.Equal(test, testCase.expectedAPIPath, httpServer.routePath(httpServer.APIUrlRoutePrefix))
+	}
+}
+
+func TestGetEventType(test *testing.T) {
+	testCases := map[string]string{
+		`{"subjectId": "6004", "messageId": "8001"}`:                      EventAddRecord,
+		`{"subjectId": "6004", "messageId": "8004"}`:                      EventDelete,
+		`{"subjectId": "6002", "messageId": "8008"}`:                      EventConfigChange,
+		`{"subjectId": "6004", "messageId": "8020", "error": "SENZ0033"}`: EventError,
+		`{"subjectId": "6004", "messageId": "8020"}`:                      "",
+		`{"subjectId": "6001", "messageId": "8001"}`:                      "",
+		"not JSON": "",
+	}
+	for message, expected := range testCases {
+		assert.Equal(test, expected, getEventType(message), message)
+	}
+}
+
+func TestGetRole(test *testing.T) {
+	testCases := []struct {
+		service  string
+		method   string
+		path     string
+		expected string
+	}{
+		{service: serviceHealth, method: http.MethodGet, path: "/readyz", expected: authentication.RoleNone},
+		{service: serviceStatus, method: http.MethodGet, path: "/status", expected: authentication.RoleViewer},
+		{service: serviceConsole, method: http.MethodGet, path: "/site/home.html", expected: authentication.RoleViewer},
+		{service: serviceConnect, method: http.MethodPost, path: "/szengine.SzEngine/GetEntityByEntityId", expected: authentication.RoleViewer},
+		{service: serviceConnect, method: http.MethodPost, path: "/szengine.SzEngine/AddRecord", expected: authentication.RoleLoader},
+		{service: serviceConnect, method: http.MethodPost, path: "/szdiagnostic.SzDiagnostic/PurgeRepository", expected: authentication.RoleAdmin},
+		{service: serviceSenzingRestAPI, method: http.MethodGet, path: "/entities/1", expected: authentication.RoleViewer},
+		{service: serviceSenzingRestAPI, method: http.MethodPost, path: "/search-entities", expected: authentication.RoleViewer},
+		{service: serviceSenzingRestAPI, method: http.MethodPost, path: "/data-sources", expected: authentication.RoleLoader},
+		{service: serviceSenzingRestAPI, method: http.MethodDelete, path: "/data-sources/TEST/records/1", expected: authentication.RoleLoader},
+		{service: serviceAudit, method: http.MethodGet, path: "", expected: authentication.RoleAdmin},
+		{service: serviceXterm, method: http.MethodGet, path: "/", expected: authentication.RoleAdmin},
+		{service: serviceJupyterLab, method: http.MethodGet, path: "/lab", expected: authentication.RoleAdmin},
+		{service: serviceSnapshots, method: http.MethodGet, path: "/", expected: authentication.RoleViewer},
+		{service: serviceSnapshots, method: http.MethodPost, path: "/", expected: authentication.RoleAdmin},
+		{service: serviceSnapshots, method: http.MethodPost, path: "/first/restore", expected: authentication.RoleAdmin},
+		{service: serviceSnapshots, method: http.MethodDelete, path: "/first", expected: authentication.RoleAdmin},
+		{service: serviceUpload, method: http.MethodGet, path: "/upload/1/progress", expected: authentication.RoleViewer},
+		{service: serviceUpload, method: http.MethodPost, path: "/upload/", expected: authentication.RoleLoader},
+		{service: serviceUpload, method: http.MethodPost, path: "/upload/1/load", expected: authentication.RoleLoader},
+		{service: serviceUpload, method: http.MethodDelete, path: "/upload/1", expected: authentication.RoleLoader},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(test, testCase.expected, getRole(testCase.service, testCase.method, testCase.path), testCase.path)
+	}
+}
+
+func TestIsAudited(test *testing.T) {
+	assert.True(test, isAudited(serviceSenzingRestAPI, authentication.RoleLoader))
+	assert.True(test, isAudited(serviceConnect, authentication.RoleAdmin))
+	assert.False(test, isAudited(serviceConnect, authentication.RoleViewer))
+	assert.False(test, isAudited(serviceHealth, authentication.RoleNone))
+	assert.False(test, isAudited(serviceAudit, authentication.RoleAdmin))
+	assert.False(test, isAudited(serviceXterm, authentication.RoleAdmin))
+}
+
+func TestBasicHTTPServer_healthzFunc(test *testing.T) {
+	ctx := context.TODO()
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.handleFuncForHealthz(response, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.JSONEq(test, `{"status": "ok"}`, response.Body.String())
+}
+
+func TestBasicHTTPServer_eventsFunc(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Observations = &observing.BufferObserver{ID: "test"}
+	httpServer.eventStreamsDone = make(chan struct{})
+	httpServer.Observations.UpdateObserver(ctx, `{"subjectId": "6004", "messageId": "8001", "recordID": "1001"}`)
+	httpServer.Observations.UpdateObserver(ctx, `{"subjectId": "6004", "messageId": "8004", "recordID": "1002"}`)
+	httpServer.Observations.UpdateObserver(ctx, `{"subjectId": "6004", "messageId": "8020", "recordID": "1003"}`)
+	server := httptest.NewServer(http.HandlerFunc(httpServer.handleFuncForEvents))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "?type=add-record&type=error,config-change")
+	require.NoError(test, err)
+	defer response.Body.Close()
+	assert.Equal(test, http.StatusOK, response.StatusCode)
+	assert.Equal(test, "text/event-stream", response.Header.Get("Content-Type"))
+	reader := bufio.NewReader(response.Body)
+	readEvent := func() string {
+		var event strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(test, err)
+			if line == "\n" {
+				return event.String()
+			}
+			event.WriteString(line)
+		}
+	}
+	assert.Equal(test, "event: add-record\ndata: {\"subjectId\": \"6004\", \"messageId\": \"8001\", \"recordID\": \"1001\"}\n", readEvent())
+
+	httpServer.Observations.UpdateObserver(ctx, `{"subjectId": "6004", "messageId": "8004", "recordID": "1004"}`)
+	httpServer.Observations.UpdateObserver(ctx, `{"subjectId": "6004", "messageId": "8001", "error": "SENZ0001"}`)
+	assert.Equal(test, "event: error\ndata: {\"subjectId\": \"6004\", \"messageId\": \"8001\", \"error\": \"SENZ0001\"}\n", readEvent())
+
+	close(httpServer.eventStreamsDone)
+	_, err = io.ReadAll(reader)
+	require.NoError(test, err, "the stream ends when the server shuts down")
+}
+
+func TestBasicHTTPServer_eventsFunc_badType(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Observations = &observing.BufferObserver{ID: "test"}
+	response := httptest.NewRecorder()
+	httpServer.handleFuncForEvents(response, httptest.NewRequest(http.MethodGet, "/events?type=add-record,update", nil))
+	assert.Equal(test, http.StatusBadRequest, response.Code)
+	assert.Contains(test, response.Body.String(), `"update"`)
+}
+
+func TestBasicHTTPServer_auditFunc(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Audit = getTestAudit(test)
+	httpServer.Audit.Record(audit.Record{Operation: "/szengine.SzEngine/AddRecord", Service: audit.ServiceGrpc, Time: time.Now().Add(-2 * time.Hour), User: "alice"})
+	httpServer.Audit.Record(audit.Record{Operation: "/szdiagnostic.SzDiagnostic/PurgeRepository", Service: audit.ServiceGrpc, User: "bob"})
+	httpServer.Audit.Record(audit.Record{Operation: audit.OperationSessionStart, Service: audit.ServiceXterm, User: "alice"})
+	getOperations := func(target string) []string {
+		response := httptest.NewRecorder()
+		httpServer.handleFuncForAudit(response, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(test, http.StatusOK, response.Code, target)
+		body := AuditResponse{}
+		require.NoError(test, json.Unmarshal(response.Body.Bytes(), &body))
+		result := []string{}
+		for _, record := range body.Records {
+			result = append(result, record.Operation)
+		}
+		return result
+	}
+	assert.Len(test, getOperations("/audit"), 3)
+	assert.Equal(test, []string{audit.OperationSessionStart}, getOperations("/audit?user=alice&since=1h"))
+	assert.Equal(test, []string{"/szdiagnostic.SzDiagnostic/PurgeRepository"}, getOperations("/audit?operation=purge"))
+	assert.Equal(test, []string{audit.OperationSessionStart}, getOperations("/audit?service=xterm"))
+	assert.Equal(test, []string{audit.OperationSessionStart}, getOperations("/audit?limit=1"))
+
+	for _, target := range []string{"/audit?since=yesterday", "/audit?limit=many", "/audit?limit=-1"} {
+		response := httptest.NewRecorder()
+		httpServer.handleFuncForAudit(response, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(test, http.StatusBadRequest, response.Code, target)
+	}
+}
+
+func TestBasicHTTPServer_getSnapshotsMux(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Snapshots = getTestSnapshots(test)
+	httpServer.uploads = &uploads{entries: map[string]*upload{}}
+	server := httptest.NewServer(httpServer.getSnapshotsMux())
+	defer server.Close()
+
+	getStatusCode := func(method string, target string, body string) int {
+		request, err := http.NewRequestWithContext(ctx, method, server.URL+target, strings.NewReader(body))
+		require.NoError(test, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(test, err)
+		defer response.Body.Close()
+		return response.StatusCode
+	}
+	assert.Equal(test, http.StatusCreated, getStatusCode(http.MethodPost, "/", `{"name": "first"}`))
+	assert.Equal(test, http.StatusConflict, getStatusCode(http.MethodPost, "/", `{"name": "first"}`))
+	assert.Equal(test, http.StatusBadRequest, getStatusCode(http.MethodPost, "/", `{"name": "../first"}`))
+	assert.Equal(test, http.StatusBadRequest, getStatusCode(http.MethodPost, "/", `not JSON`))
+
+	response, err := http.Get(server.URL + "/")
+	require.NoError(test, err)
+	defer response.Body.Close()
+	require.Equal(test, http.StatusOK, response.StatusCode)
+	body := SnapshotsResponse{}
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&body))
+	require.Len(test, body.Snapshots, 1)
+	assert.Equal(test, "first", body.Snapshots[0].Name)
+
+	assert.Equal(test, http.StatusNoContent, getStatusCode(http.MethodPost, "/first/restore", ""))
+	assert.Equal(test, http.StatusNoContent, getStatusCode(http.MethodPost, "/"+snapshot.BaselineName+"/restore", ""))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodPost, "/missing/restore", ""))
+	httpServer.uploads.isLoading = true
+	assert.Equal(test, http.StatusConflict, getStatusCode(http.MethodPost, "/first/restore", ""), "while loading")
+	httpServer.uploads.isLoading = false
+	httpServer.uploads.isRestoring = true
+	assert.Equal(test, http.StatusConflict, getStatusCode(http.MethodPost, "/first/restore", ""), "while restoring")
+	httpServer.uploads.isRestoring = false
+	assert.Equal(test, http.StatusNoContent, getStatusCode(http.MethodDelete, "/first", ""))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodDelete, "/first", ""))
+}
+
+func TestBasicHTTPServer_getUploadMux(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.uploads = &uploads{entries: map[string]*upload{}}
+	server := httptest.NewServer(httpServer.getUploadMux(ctx))
+	defer server.Close()
+
+	response, err := postTestUpload(server.URL+"/", "customers.csv", "DATA_SOURCE,RECORD_ID,NAME_FULL\nCUSTOMERS,1,Robert Smith\nCUSTOMERS,2,\n")
+	require.NoError(test, err)
+	defer response.Body.Close()
+	require.Equal(test, http.StatusCreated, response.StatusCode)
+	status := UploadStatus{}
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&status))
+	assert.Equal(test, "customers.csv", status.File)
+	assert.Equal(test, UploadStateUploaded, status.State)
+	assert.Equal(test, int64(2), status.Analysis.Records)
+	assert.Len(test, status.Analysis.Samples, 2)
+	assert.Equal(test, []string{"DATA_SOURCE", "RECORD_ID", "NAME_FULL"}, status.Header)
+	require.NotNil(test, status.Mapping)
+	assert.Equal(test, loader.Column{Attribute: "NAME_FULL", Name: "NAME_FULL"}, status.Mapping.Columns[2])
+
+	getStatusCode := func(method string, target string) int {
+		request, err := http.NewRequestWithContext(ctx, method, server.URL+target, nil)
+		require.NoError(test, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(test, err)
+		defer response.Body.Close()
+		return response.StatusCode
+	}
+	assert.Equal(test, http.StatusOK, getStatusCode(http.MethodGet, "/"+status.ID))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodGet, "/unknown"))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodGet, "/"+status.ID+"/failures"), "not loaded yet")
+	assert.Equal(test, http.StatusServiceUnavailable, getStatusCode(http.MethodPost, "/"+status.ID+"/load"), "no gRPC connection")
+	require.True(test, httpServer.uploads.startRestore())
+	require.False(test, httpServer.uploads.startRestore(), "one restore at a time")
+	_, statusCode := httpServer.uploads.startLoad(status.ID)
+	assert.Equal(test, http.StatusConflict, statusCode, "while restoring")
+	httpServer.uploads.finishRestore()
+
+	putMapping := func(body string) (UploadStatus, int) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL+"/"+status.ID+"/mapping", strings.NewReader(body))
+		require.NoError(test, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(test, err)
+		defer response.Body.Close()
+		result := UploadStatus{}
+		if response.StatusCode == http.StatusOK {
+			require.NoError(test, json.NewDecoder(response.Body).Decode(&result))
+		}
+		return result, response.StatusCode
+	}
+	mapped, statusCode := putMapping(`{"columns": [{"name": "RECORD_ID", "attribute": "RECORD_ID"}, {"name": "NAME_FULL", "attribute": "NAME_ORG"}], "dataSource": "COMPANIES"}`)
+	require.Equal(test, http.StatusOK, statusCode)
+	assert.Equal(test, "COMPANIES", mapped.Mapping.DataSource)
+	assert.Equal(test, []loader.Count{{Count: 2, Name: "COMPANIES"}}, mapped.Analysis.DataSources)
+	assert.JSONEq(test, `{"DATA_SOURCE": "COMPANIES", "RECORD_ID": "1", "NAME_ORG": "Robert Smith"}`, string(mapped.Analysis.Samples[0]))
+	_, statusCode = putMapping(`{"columns": [{"name": "NAME_FULL", "attribute": "NAME_ORG"}]}`)
+	assert.Equal(test, http.StatusBadRequest, statusCode, "no data source")
+	_, statusCode = putMapping(`not JSON`)
+	assert.Equal(test, http.StatusBadRequest, statusCode)
+	files, err := os.ReadDir(httpServer.uploads.dir)
+	require.NoError(test, err)
+	assert.Len(test, files, 2, "the original, and the file converted from it")
+
+	dir := httpServer.uploads.dir
+	httpServer.uploads.removeAll()
+	assert.NoDirExists(test, dir)
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodGet, "/"+status.ID))
+}
+
+func TestBasicHTTPServer_getUploadMux_delete(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.UploadMaxCount = 2
+	httpServer.uploads = &uploads{entries: map[string]*upload{}}
+	server := httptest.NewServer(httpServer.getUploadMux(ctx))
+	defer server.Close()
+	defer httpServer.uploads.removeAll()
+
+	postUpload := func(filename string) string {
+		response, err := postTestUpload(server.URL+"/", filename, "DATA_SOURCE,RECORD_ID,NAME_FULL\nCUSTOMERS,1,Robert Smith\n")
+		require.NoError(test, err)
+		defer response.Body.Close()
+		require.Equal(test, http.StatusCreated, response.StatusCode)
+		status := UploadStatus{}
+		require.NoError(test, json.NewDecoder(response.Body).Decode(&status))
+		return status.ID
+	}
+	getStatusCode := func(method string, target string) int {
+		request, err := http.NewRequestWithContext(ctx, method, server.URL+target, nil)
+		require.NoError(test, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(test, err)
+		defer response.Body.Close()
+		return response.StatusCode
+	}
+
+	// The oldest upload not being loaded makes room for a new one.
+
+	first := postUpload("first.csv")
+	second := postUpload("second.csv")
+	httpServer.uploads.update(first, func(status *UploadStatus) { status.State = UploadStateLoading })
+	assert.Equal(test, http.StatusConflict, getStatusCode(http.MethodDelete, "/"+first), "while loading")
+	third := postUpload("third.csv")
+	assert.Equal(test, http.StatusOK, getStatusCode(http.MethodGet, "/"+first))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodGet, "/"+second))
+	assert.Equal(test, http.StatusOK, getStatusCode(http.MethodGet, "/"+third))
+	files, err := os.ReadDir(httpServer.uploads.dir)
+	require.NoError(test, err)
+	assert.Len(test, files, 4, "the original and converted files of the first and third uploads")
+
+	httpServer.uploads.update(first, func(status *UploadStatus) { status.State = UploadStateDone })
+	assert.Equal(test, http.StatusNoContent, getStatusCode(http.MethodDelete, "/"+first))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodDelete, "/"+first))
+	assert.Equal(test, http.StatusNotFound, getStatusCode(http.MethodGet, "/"+first))
+	files, err = os.ReadDir(httpServer.uploads.dir)
+	require.NoError(test, err)
+	assert.Len(test, files, 2)
+}
+
+func TestBasicHTTPServer_getUploadMux_tooLarge(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.UploadMaxSize = 1024
+	httpServer.uploads = &uploads{entries: map[string]*upload{}}
+	defer httpServer.uploads.removeAll()
+	server := httptest.NewServer(httpServer.getUploadMux(ctx))
+	defer server.Close()
+
+	response, err := postTestUpload(server.URL+"/", "customers.jsonl", strings.Repeat(`{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1"}`+"\n", 100))
+	require.NoError(test, err)
+	defer response.Body.Close()
+	assert.Equal(test, http.StatusRequestEntityTooLarge, response.StatusCode)
+	assert.Empty(test, httpServer.uploads.entries)
+}
+
+func TestBasicHTTPServer_observationsFunc(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Observations = &observing.BufferObserver{ID: "test"}
+	httpServer.Observations.UpdateObserver(ctx, `{"messageId": "8001"}`)
+	httpServer.Observations.UpdateObserver(ctx, "not JSON")
+	response := httptest.NewRecorder()
+	httpServer.handleFuncForObservations(response, httptest.NewRequest(http.MethodGet, "/observations", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.JSONEq(test, `{"messages": [{"messageId": "8001"}, "not JSON"]}`, response.Body.String())
+}
+
+func TestBasicHTTPServer_readyzFunc(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	response := httptest.NewRecorder()
+	httpServer.handleFuncForReadyz(response, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(test, http.StatusServiceUnavailable, response.Code)
+	assert.JSONEq(test, `{"ready": false, "status": "starting"}`, response.Body.String())
+
+	httpServer.healthProber = &testHealthProber{
+		statuses: []healthprobe.ServiceStatus{{Name: "senzing-engine", State: healthprobe.StateUp}},
+	}
+	response = httptest.NewRecorder()
+	httpServer.handleFuncForReadyz(response, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.JSONEq(test, `{"ready": true, "status": "up"}`, response.Body.String())
+}
+
+func TestBasicHTTPServer_statusFunc(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Version = "1.2.3"
+	httpServer.healthProber = &testHealthProber{
+		statuses: []healthprobe.ServiceStatus{
+			{Checked: time.Now(), Latency: 2 * time.Millisecond, Name: "senzing-engine", State: healthprobe.StateUp},
+			{Checked: time.Now(), LastError: "connection refused", LastErrorAt: time.Now(), Name: "jupyter-lab", State: healthprobe.StateDown},
+		},
+	}
+	httpServer.Supervisor = &testSupervisor{
+		statuses: []supervisor.ProgramStatus{{Name: "jupyter-lab", State: supervisor.StateBackoff}},
+	}
+	response := httptest.NewRecorder()
+	httpServer.handleFuncForStatus(response, httptest.NewRequest(http.MethodGet, "/status", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Equal(test, "application/json", response.Header().Get("Content-Type"))
+	status := StatusResponse{}
+	require.NoError(test, json.Unmarshal(response.Body.Bytes(), &status))
+	assert.True(test, status.Ready)
+	assert.Equal(test, StatusDegraded, status.Status)
+	assert.Equal(test, "1.2.3", status.Version.Playground)
+	require.Len(test, status.Services, 2)
+	assert.Equal(test, "senzing-engine", status.Services[0].Name)
+	assert.InDelta(test, 2.0, status.Services[0].LatencyMilliseconds, 0.001)
+	assert.Nil(test, status.Services[0].LastErrorAt)
+	assert.Equal(test, "connection refused", status.Services[1].LastError)
+	require.Len(test, status.Programs, 1)
+	assert.Equal(test, supervisor.StateBackoff, status.Programs[0].State)
+}
+
 func TestBasicHTTPServer_siteFunc(test *testing.T) {
 	_ = test
 	ctx := context.TODO()
@@ -76,10 +907,342 @@ func TestBasicHTTPServer_siteFunc(test *testing.T) {
 	httpServer.handleFuncForSite(response, request)
 }
 
+func TestBasicHTTPServer_siteFunc_programs(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/site/extras.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Supervisor = &testSupervisor{
+		statuses: []supervisor.ProgramStatus{{Name: "jupyter-lab", PID: 1234, State: supervisor.StateRunning}},
+	}
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), "<td>jupyter-lab</td>")
+	assert.Contains(test, response.Body.String(), "<td>1234</td>")
+}
+
+func TestBasicHTTPServer_siteFunc_viewer(test *testing.T) {
+	ctx := context.TODO()
+	httpServer := getTestObject(ctx, test)
+	httpServer.Authentication = &authentication.BasicAuthentication{}
+	request := httptest.NewRequest(http.MethodGet, "/site/extras.html", nil)
+	identity := &authentication.Identity{Name: "viewer", Role: authentication.RoleViewer}
+	response := httptest.NewRecorder()
+	httpServer.handleFuncForSite(response, request.WithContext(authentication.ContextWithIdentity(ctx, identity)))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.NotContains(test, response.Body.String(), "/xterm", "xterm is for admins")
+	identity.Role = authentication.RoleAdmin
+	response = httptest.NewRecorder()
+	httpServer.handleFuncForSite(response, request.WithContext(authentication.ContextWithIdentity(ctx, identity)))
+	assert.Contains(test, response.Body.String(), "/xterm")
+}
+
+func TestBasicHTTPServer_siteFunc_health(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/site/extras.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.healthProber = &testHealthProber{
+		statuses: []healthprobe.ServiceStatus{
+			{Checked: time.Now(), Name: "jupyter-lab", State: healthprobe.StateDown, LastError: "connection refused", LastErrorAt: time.Now()},
+			{Checked: time.Now(), Latency: 1500 * time.Microsecond, Name: "senzing-engine", State: healthprobe.StateUp},
+		},
+	}
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	body := response.Body.String()
+	assert.Contains(test, body, "<td>senzing-engine</td>")
+	assert.Contains(test, body, "<td>1.5ms</td>")
+	assert.Contains(test, body, "connection refused")
+	assert.NotContains(test, body, `href="http://example.com/jupyter"`, "a service that is down has no link")
+}
+
+func TestBasicHTTPServer_siteFunc_basePath(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/playground/site/home.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.BasePath = "/playground"
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `href="/playground/css/site.css"`)
+}
+
+func TestBasicHTTPServer_siteFunc_events(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/site/events.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), "Observations are not enabled.")
+
+	response = httptest.NewRecorder()
+	httpServer.Observations = &observing.BufferObserver{ID: "test"}
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `id="filter-form"`)
+}
+
+func TestBasicHTTPServer_siteFunc_audit(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/site/audit.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), "The audit log is not enabled.")
+
+	response = httptest.NewRecorder()
+	httpServer.Audit = getTestAudit(test)
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `id="filter-form"`)
+}
+
+func TestBasicHTTPServer_siteFunc_upload(test *testing.T) {
+	ctx := context.TODO()
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.handleFuncForSite(response, httptest.NewRequest(http.MethodGet, "/site/upload.html", nil))
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `id="drop-zone"`)
+	assert.Contains(test, response.Body.String(), `<option value="NAME_FIRST"></option>`)
+}
+
+func TestBasicHTTPServer_siteFunc_snapshots(test *testing.T) {
+	ctx := context.TODO()
+	request := httptest.NewRequest(http.MethodGet, "/site/snapshots.html", nil)
+	response := httptest.NewRecorder()
+	httpServer := getTestObject(ctx, test)
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), "Snapshots are not available")
+
+	response = httptest.NewRecorder()
+	httpServer.Snapshots = getTestSnapshots(test)
+	httpServer.handleFuncForSite(response, request)
+	assert.Equal(test, http.StatusOK, response.Code)
+	assert.Contains(test, response.Body.String(), `id="create-form"`)
+}
+
+func TestBasicHTTPServer_logAccess(test *testing.T) {
+	ctx := context.TODO()
+	var output bytes.Buffer
+	httpServer := getTestObject(ctx, test)
+	httpServer.logger = getTestLogger(test, &output, "INFO")
+	handler := httpServer.logAccess(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	}), func(r *http.Request) string { return serviceConsole })
+	request := httptest.NewRequest(http.MethodPost, "/site/home.html", nil)
+	request.RemoteAddr = "192.0.2.1:1234"
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	message := struct {
+		Details []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"details"`
+		ID string `json:"id"`
+	}{}
+	require.NoError(test, json.Unmarshal(output.Bytes(), &message))
+	assert.Equal(test, "SZTL62142001", message.ID)
+	details := map[string]string{}
+	for _, detail := range message.Details {
+		details[detail.Key] = detail.Value
+	}
+	assert.Equal(test, "5", details["bytes"])
+	assert.NotEmpty(test, details["durationMilliseconds"])
+	assert.Equal(test, http.MethodPost, details["method"])
+	assert.Equal(test, "/site/home.html", details["path"])
+	assert.Equal(test, "192.0.2.1:1234", details["remoteAddress"])
+	assert.Equal(test, serviceConsole, details["service"])
+	assert.Equal(test, "201", details["status"])
+}
+
+func TestBasicHTTPServer_logAccess_logLevel(test *testing.T) {
+	ctx := context.TODO()
+	var output bytes.Buffer
+	httpServer := getTestObject(ctx, test)
+	httpServer.logger = getTestLogger(test, &output, "WARN")
+	handler := httpServer.logAccess(http.NotFoundHandler(), func(r *http.Request) string { return serviceNone })
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(test, http.StatusNotFound, response.Code)
+	assert.Empty(test, output.String())
+}
+
+func TestBasicHTTPServer_populateStaticTemplate_missing(test *testing.T) {
+	ctx := context.TODO()
+	var output bytes.Buffer
+	httpServer := getTestObject(ctx, test)
+	httpServer.logger = getTestLogger(test, &output, "INFO")
+	response := httptest.NewRecorder()
+	httpServer.populateStaticTemplate(response, httptest.NewRequest(http.MethodGet, "/site/missing.html", nil), "static/templates/missing.html", TemplateVariables{})
+	assert.Equal(test, http.StatusInternalServerError, response.Code)
+	assert.Contains(test, output.String(), `"id":"SZTL62144001"`)
+}
+
+func TestBufferedResponse_sendTo(test *testing.T) {
+	buffered := &bufferedResponse{}
+	buffered.Header().Set("Content-Length", "18")
+	buffered.Header().Set("Content-Type", "application/json")
+	buffered.WriteHeader(http.StatusNotFound)
+	buffered.WriteHeader(http.StatusOK)
+	_, err := buffered.Write([]byte(`"/entity-search/api"`))
+	require.NoError(test, err)
+	response := httptest.NewRecorder()
+	buffered.sendTo(response, strings.NewReplacer(`"/entity-search`, `"/playground/entity-search`))
+	assert.Equal(test, http.StatusNotFound, response.Code, "the first status code counts")
+	assert.Equal(test, "application/json", response.Header().Get("Content-Type"))
+	assert.Empty(test, response.Header().Get("Content-Length"), "the body's length changes")
+	assert.Equal(test, `"/playground/entity-search/api"`, response.Body.String())
+
+	buffered = &bufferedResponse{}
+	_, err = buffered.Write([]byte("body"))
+	require.NoError(test, err)
+	response = httptest.NewRecorder()
+	buffered.sendTo(response, strings.NewReplacer())
+	assert.Equal(test, http.StatusOK, response.Code)
+}
+
+func TestXtermSessions_closeAll(test *testing.T) {
+	sessions := &xtermSessions{
+		connections: map[net.Conn]struct{}{},
+	}
+	client, server := net.Pipe()
+	defer client.Close()
+	handler := sessions.track(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(test, 1, sessions.closeAll())
+	}))
+	request := httptest.NewRequest(http.MethodGet, "/xterm.js", nil)
+	request.Header.Set("Upgrade", "websocket")
+	request = request.WithContext(context.WithValue(request.Context(), connectionContextKey, server))
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Equal(test, 0, sessions.closeAll())
+}
+
+func TestXtermSessions_track_audit(test *testing.T) {
+	sessions := &xtermSessions{
+		audit:       getTestAudit(test),
+		connections: map[net.Conn]struct{}{},
+	}
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	handler := sessions.track(http.NotFoundHandler())
+	request := httptest.NewRequest(http.MethodGet, "/xterm.js", nil)
+	request.Header.Set("Upgrade", "websocket")
+	identity := &authentication.Identity{Name: "root", Role: authentication.RoleAdmin}
+	ctx := authentication.ContextWithIdentity(context.WithValue(request.Context(), connectionContextKey, server), identity)
+	handler.ServeHTTP(httptest.NewRecorder(), request.WithContext(ctx))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/xterm.html", nil))
+	records, err := sessions.audit.Query(audit.Filter{})
+	require.NoError(test, err)
+	require.Len(test, records, 2, "only websockets are sessions")
+	assert.Equal(test, audit.OperationSessionStart, records[0].Operation)
+	assert.Equal(test, audit.OperationSessionStop, records[1].Operation)
+	assert.Equal(test, "root", records[1].User)
+	assert.Equal(test, audit.ServiceXterm, records[1].Service)
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
 
+func getTestAudit(test *testing.T) *audit.BasicAudit {
+	result := &audit.BasicAudit{
+		File: filepath.Join(test.TempDir(), "audit.log"),
+	}
+	require.NoError(test, result.Open())
+	test.Cleanup(func() {
+		_ = result.Close()
+	})
+	return result
+}
+
+// testSupervisor reports fixed program statuses.
+type testSupervisor struct {
+	statuses []supervisor.ProgramStatus
+}
+
+func (testSupervisor *testSupervisor) Restart(ctx context.Context) error {
+	_ = ctx
+	return nil
+}
+
+func (testSupervisor *testSupervisor) Serve(ctx context.Context) error {
+	_ = ctx
+	return nil
+}
+
+func (testSupervisor *testSupervisor) Status() []supervisor.ProgramStatus {
+	return testSupervisor.statuses
+}
+
+// testHealthProber reports fixed service statuses.
+type testHealthProber struct {
+	statuses []healthprobe.ServiceStatus
+}
+
+func (testHealthProber *testHealthProber) Serve(ctx context.Context) error {
+	_ = ctx
+	return nil
+}
+
+func (testHealthProber *testHealthProber) Status() []healthprobe.ServiceStatus {
+	return append([]healthprobe.ServiceStatus{}, testHealthProber.statuses...)
+}
+
+// A logger for the HTTP server that writes to output.
+func getTestLogger(test *testing.T, output io.Writer, logLevelName string) logging.Logging {
+	logger, err := logging.NewSenzingLogger(ComponentID, IDMessages,
+		logging.OptionLogLevel{Value: logLevelName},
+		logging.OptionMessageFields{Value: []string{"id", "text", "reason", "errors", "details"}},
+		logging.OptionOutput{Value: output},
+	)
+	require.NoError(test, err)
+	return logger
+}
+
+// Snapshots of a copy of the baseline repository, which is also the baseline.
+func getTestSnapshots(test *testing.T) *snapshot.BasicSnapshots {
+	directory := test.TempDir()
+	baseline, err := os.ReadFile("../testdata/sqlite/G2C-with-config.db")
+	require.NoError(test, err)
+	databaseFile := filepath.Join(directory, "G2C.db")
+	require.NoError(test, os.WriteFile(databaseFile, baseline, 0600))
+	result := &snapshot.BasicSnapshots{
+		DatabaseFile: databaseFile,
+		Directory:    filepath.Join(directory, "snapshots"),
+	}
+	_, err = result.SaveBaseline(context.TODO(), func(ctx context.Context, file string) error {
+		_ = ctx
+		return os.WriteFile(file, baseline, 0600)
+	})
+	require.NoError(test, err)
+	return result
+}
+
+func postTestUpload(url string, filename string, contents string) (*http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	_, err = part.Write([]byte(contents))
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return http.Post(url, writer.FormDataContentType(), body)
+}
+
 func getTestObject(ctx context.Context, test *testing.T) *BasicHTTPServer {
 	_ = ctx
 
@@ -101,6 +1264,7 @@ func getTestObject(ctx context.Context, test *testing.T) *BasicHTTPServer {
 		AvoidServing:             true,
 		EnableAll:                true,
 		EntitySearchRoutePrefix:  "entity-search",
+		GrpcPort:                 8261,
 		JupyterLabRoutePrefix:    "jupyter",
 		LogLevelName:             logLevelName,
 		ObserverOrigin:           "Test Observer origin",
@@ -109,6 +1273,7 @@ func getTestObject(ctx context.Context, test *testing.T) *BasicHTTPServer {
 		ReadHeaderTimeout:        10 * time.Second,
 		SenzingInstanceName:      "Test HTTP Server",
 		SenzingSettings:          senzingSettings,
+		ShutdownTimeout:          5 * time.Second,
 		SwaggerURLRoutePrefix:    "swagger",
 		TtyOnly:                  true,
 		XtermURLRoutePrefix:      "xterm",
@@ -2,6 +2,14 @@ package httpserver
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/senzing-garage/playground/audit"
+	"github.com/senzing-garage/playground/loader"
+	"github.com/senzing-garage/playground/snapshot"
+	"github.com/senzing-garage/playground/supervisor"
 )
 
 // ----------------------------------------------------------------------------
@@ -12,3 +20,209 @@ import (
 type HTTPServer interface {
 	Serve(ctx context.Context) error
 }
+
+// AuditResponse is the body of the "/audit" endpoint.  Records are oldest first.
+type AuditResponse struct {
+	Records []audit.Record `json:"records"`
+}
+
+// ObservationsResponse is the body of the "/observations" endpoint.
+// Each message is a Senzing observer message, oldest first.
+type ObservationsResponse struct {
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// observedCall identifies a Senzing SDK call by the subjectId and messageId of its observer message.
+type observedCall struct {
+	messageID string
+	subjectID string
+}
+
+// ReadinessResponse is the body of the readiness endpoint, "/readyz".
+type ReadinessResponse struct {
+	Ready  bool   `json:"ready"`
+	Status string `json:"status"`
+}
+
+// ServiceHealth reports the latest health probe of a service.
+type ServiceHealth struct {
+	Checked             *time.Time `json:"checked,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time `json:"lastErrorAt,omitempty"`
+	LatencyMilliseconds float64    `json:"latencyMilliseconds"`
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+}
+
+// SnapshotRequest is the body of a request to save a snapshot, posted to "/snapshots/".
+type SnapshotRequest struct {
+	Name string `json:"name"`
+}
+
+// SnapshotsResponse is the body of the "/snapshots/" endpoint.  Snapshots are oldest first.
+type SnapshotsResponse struct {
+	Snapshots []snapshot.Snapshot `json:"snapshots"`
+}
+
+// StatusResponse is the body of the detailed status endpoint, "/status".
+type StatusResponse struct {
+	Programs []supervisor.ProgramStatus `json:"programs"`
+	Ready    bool                       `json:"ready"`
+	Services []ServiceHealth            `json:"services"`
+	Status   string                     `json:"status"`
+	Version  VersionInfo                `json:"version"`
+}
+
+// UploadStatus is the body of the "/upload" endpoints: an uploaded file, the records it holds,
+// and, once loading has started, its progress; once loaded, how its records resolved.
+// For a CSV or TSV file, it also has the column names and how they map to Senzing attributes.
+type UploadStatus struct {
+	Analysis loader.Analysis `json:"analysis"`
+	Error    string          `json:"error,omitempty"`
+	File     string          `json:"file"`
+	Header   []string        `json:"header,omitempty"`
+	ID       string          `json:"id"`
+	Mapping  *loader.Mapping `json:"mapping,omitempty"`
+	Progress *loader.Result  `json:"progress,omitempty"`
+	State    string          `json:"state"`
+	Summary  *loader.Summary `json:"summary,omitempty"`
+}
+
+// VersionInfo reports the versions of the playground and of Senzing.
+type VersionInfo struct {
+	Playground string          `json:"playground,omitempty"`
+	Senzing    json.RawMessage `json:"senzing,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identfier of the  package found messages having the format "senzing-6214xxxx".
+const ComponentID = 6214
+
+// DefaultUploadMaxCount is how many uploaded files "/upload" keeps unless UploadMaxCount says otherwise.
+const DefaultUploadMaxCount = 20
+
+// DefaultUploadMaxSize is the largest file, in bytes, accepted by "/upload" unless UploadMaxSize says otherwise.
+const DefaultUploadMaxSize = 100 * 1024 * 1024
+
+// Addresses of the services that are proxied.
+const (
+	jupyterLabTarget       = "http://localhost:8888"
+	senzingPocServerTarget = "http://localhost:8250"
+)
+
+// Names of services, as used by health probes, metrics, and access logs.
+const (
+	serviceAudit          = "audit"
+	serviceConnect        = "connect"
+	serviceConsole        = "console"
+	serviceDatabase       = "database"
+	serviceEntitySearch   = "entity-search"
+	serviceEvents         = "events"
+	serviceGrpc           = "grpc"
+	serviceHealth         = "health"
+	serviceJupyterLab     = "jupyter-lab"
+	serviceMetrics        = "metrics"
+	serviceNone           = "none"
+	serviceObservations   = "observations"
+	serviceSenzingEngine  = "senzing-engine"
+	serviceSenzingRestAPI = "senzing-rest-api"
+	serviceSnapshots      = "snapshots"
+	serviceStatus         = "status"
+	serviceSwaggerUI      = "swagger-ui"
+	serviceUpload         = "upload"
+	serviceXterm          = "xterm"
+)
+
+// Types of the events streamed at "/events".
+const (
+	EventAddRecord    = "add-record"
+	EventConfigChange = "config-change"
+	EventDelete       = "delete"
+	EventError        = "error"
+)
+
+// Most records "/audit" returns, unless its "limit" parameter says otherwise.
+const auditDefaultLimit = 500
+
+// How often an idle event stream is sent a comment, so proxies keep it open.
+const eventsKeepAliveInterval = 15 * time.Second
+
+// States of an uploaded file, as reported by the "/upload" endpoints.
+const (
+	UploadStateDone        = "done"
+	UploadStateFailed      = "failed"
+	UploadStateLoading     = "loading"
+	UploadStateSummarizing = "summarizing"
+	UploadStateUploaded    = "uploaded"
+)
+
+// Largest mapping accepted for an uploaded file, in bytes.
+const uploadMappingMaxSize = 1024 * 1024
+
+// How often the progress of loading an uploaded file is streamed.
+const uploadProgressInterval = time.Second
+
+// Largest request to save a snapshot, in bytes.
+const snapshotRequestMaxSize = 4096
+
+// Number of records of an uploaded file returned as examples.
+const uploadSamples = 5
+
+// Overall states reported by the readiness and status endpoints.
+const (
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+	StatusStarting = "starting"
+	StatusUp       = "up"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrShutdownTimeout is returned by Serve when in-flight requests did not
+// finish before the ShutdownTimeout elapsed and the server was closed forcibly.
+var ErrShutdownTimeout = errors.New("httpserver: in-flight requests did not finish before the shutdown timeout")
+
+// Message templates for the HTTP server.  See docs/errors.md.
+var IDMessages = map[int]string{
+	2001: "HTTP request served.",
+	2002: "Shutting down HTTP server. Waiting up to %v for in-flight requests to finish.",
+	2003: "Closed %d xterm session(s).",
+	2004: "Saved snapshot %s of the repository.",
+	2005: "Restored the repository from snapshot %s.",
+	3001: "In-flight HTTP requests did not finish within %v. Forcing close.",
+	4001: "Could not read template %s.",
+	4002: "Could not parse template %s.",
+	4003: "Could not render template %s.",
+	4004: "Could not render the OpenAPI specification.",
+	4005: "Could not proxy %s %s to %s.",
+	4006: "Could not load uploaded file %s.",
+	4007: "Could not restore the repository from snapshot %s.",
+}
+
+// Event types of the Senzing SDK calls streamed at "/events".
+// Any call that fails is streamed as EventError.
+var eventTypes = map[observedCall]string{
+	{subjectID: "6002", messageID: "8001"}: EventConfigChange, // szconfigmanager.AddConfig
+	{subjectID: "6002", messageID: "8007"}: EventConfigChange, // szconfigmanager.ReplaceDefaultConfigID
+	{subjectID: "6002", messageID: "8008"}: EventConfigChange, // szconfigmanager.SetDefaultConfigID
+	{subjectID: "6004", messageID: "8001"}: EventAddRecord,    // szengine.AddRecord
+	{subjectID: "6004", messageID: "8004"}: EventDelete,       // szengine.DeleteRecord
+}
+
+// Paths of the Senzing REST API that are read with POST, so need only the viewer role.
+var restAPIQueryPaths = map[string]bool{
+	"/bulk-data/analyze": true,
+	"/search-entities":   true,
+}
+
+// Health probes that must be up, or degraded, for the server to be ready.
+var readinessProbes = map[string]bool{
+	serviceDatabase:      true,
+	serviceGrpc:          true,
+	serviceSenzingEngine: true,
+}
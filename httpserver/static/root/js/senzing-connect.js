@@ -0,0 +1,63 @@
+// Call Senzing gRPC services from the browser using the Connect protocol.
+//
+// Example:
+//   const response = await senzingCall(connectURL, "szengine.SzEngine", "GetEntityByRecordId",
+//     { dataSourceCode: "CUSTOMERS", recordId: "1001" });
+//   const entity = JSON.parse(response.result);
+//
+// Request and response fields use the lowerCamelCase names of the .proto messages.
+// On failure, the promise is rejected with an Error whose "code" is the Connect error code.
+
+async function senzingCall(connectURL, service, method, request) {
+  const response = await fetch(`${connectURL}/${service}/${method}`, {
+    method: "POST",
+    headers: {
+      "Connect-Protocol-Version": "1",
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(request || {}),
+  });
+  const body = await response.json().catch(() => ({}));
+  if (!response.ok) {
+    const error = new Error(body.message || response.statusText);
+    error.code = body.code || "unknown";
+    throw error;
+  }
+  return body;
+}
+
+// Senzing flag values.  64-bit values are strings, because JavaScript numbers lose precision past 2^53.
+
+const SZ_ENTITY_DEFAULT_FLAGS = "3734464";
+const SZ_NO_FLAGS = "0";
+const SZ_SEARCH_BY_ATTRIBUTES_DEFAULT_FLAGS = "67123215";
+const SZ_WITH_INFO = "4611686018427387904";
+
+// Convenience wrappers for common SzEngine calls.  Each resolves to the parsed JSON result.
+
+async function senzingAddRecord(connectURL, dataSourceCode, recordId, recordDefinition, flags = SZ_NO_FLAGS) {
+  const response = await senzingCall(connectURL, "szengine.SzEngine", "AddRecord", {
+    dataSourceCode: dataSourceCode,
+    recordId: recordId,
+    recordDefinition: typeof recordDefinition === "string" ? recordDefinition : JSON.stringify(recordDefinition),
+    flags: flags,
+  });
+  return response.result ? JSON.parse(response.result) : {};
+}
+
+async function senzingGetEntityByRecordId(connectURL, dataSourceCode, recordId, flags = SZ_ENTITY_DEFAULT_FLAGS) {
+  const response = await senzingCall(connectURL, "szengine.SzEngine", "GetEntityByRecordId", {
+    dataSourceCode: dataSourceCode,
+    recordId: recordId,
+    flags: flags,
+  });
+  return JSON.parse(response.result);
+}
+
+async function senzingSearchByAttributes(connectURL, attributes, flags = SZ_SEARCH_BY_ATTRIBUTES_DEFAULT_FLAGS) {
+  const response = await senzingCall(connectURL, "szengine.SzEngine", "SearchByAttributes", {
+    attributes: typeof attributes === "string" ? attributes : JSON.stringify(attributes),
+    flags: flags,
+  });
+  return JSON.parse(response.result);
+}
@@ -0,0 +1,11 @@
+/*
+Package loader adds the records of a JSON Lines file to a Senzing repository,
+through any senzing.SzAbstractFactory: the embedded engine or a remote gRPC server.
+Data sources the records name are registered first.  Records are added in parallel,
+records that cannot be added are written to a failures file, and a checkpoint
+file lets an interrupted load resume where it stopped.
+
+CSV and TSV files are converted to JSON Lines by ConvertCSV, with a Mapping
+of their columns to Senzing attributes, which SuggestMapping guesses from the column names.
+*/
+package loader
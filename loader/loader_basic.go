@@ -0,0 +1,1045 @@
+package loader
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicLoader adds the records of InputFile, a file of JSON Lines, through Factory.
+Each line is a record with DATA_SOURCE and RECORD_ID fields; blank lines are ignored.
+Data sources missing from the default configuration are added to a new configuration,
+which becomes the default.
+Threads records are added at a time, DefaultThreads if not set.
+Every ProgressInterval, DefaultProgressInterval if not set, progress and throughput
+are written to Output and passed to Progress, and the line reached is written to CheckpointFile.
+Records that cannot be added are written to FailuresFile as Failures.
+With Resume, the lines up to the one in CheckpointFile are skipped, and FailuresFile
+keeps the failures of those lines rather than being replaced.
+*/
+type BasicLoader struct {
+	CheckpointFile   string
+	Factory          senzing.SzAbstractFactory
+	FailuresFile     string
+	InputFile        string
+	Output           io.Writer
+	Progress         func(result Result)
+	ProgressInterval time.Duration
+	Resume           bool
+	Threads          int
+}
+
+// A line of the input file, numbered from 1.
+type inputLine struct {
+	number int64
+	text   string
+}
+
+// What became of an inputLine.
+type outcome struct {
+	failure *Failure
+	isBlank bool
+	number  int64
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Load method registers the data sources named in InputFile, then adds its records.
+When ctx is cancelled, the records being added are finished, the checkpoint is written,
+and ErrInterrupted is returned.  Once every line is loaded or failed, CheckpointFile is removed.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - The data sources registered, and the records loaded, failed, and skipped.
+*/
+func (loader *BasicLoader) Load(ctx context.Context) (Result, error) {
+	start := time.Now()
+	result := Result{}
+	if len(loader.InputFile) == 0 {
+		return result, ErrMissingFile
+	}
+	startLine, err := loader.readCheckpoint()
+	if err != nil {
+		return result, err
+	}
+	if startLine > 0 {
+		loader.printf("Resuming %s after line %d.\n", loader.InputFile, startLine)
+	}
+
+	// Find the data sources and count the records.
+
+	dataSources, err := scanFile(loader.InputFile, startLine, &result)
+	if err != nil {
+		return result, err
+	}
+	result.DataSources, err = loader.registerDataSources(ctx, dataSources)
+	if err != nil {
+		return result, err
+	}
+	if len(result.DataSources) > 0 {
+		loader.printf("Registered data sources: %s.\n", strings.Join(result.DataSources, ", "))
+	}
+
+	// Add the records.
+
+	err = loader.loadRecords(ctx, startLine, start, &result)
+	loader.reportProgress(&result, start)
+	if result.Failed > 0 && len(loader.FailuresFile) > 0 {
+		loader.printf("Failures written to %s.\n", loader.FailuresFile)
+	}
+	return result, err
+}
+
+/*
+The Compare method finds the columns of a CSV file's header row the Mapping says nothing about,
+and the columns the Mapping maps that the header row lacks.
+
+Input
+  - header: The column names in the header row.
+
+Output
+  - The names of the columns left out, and of the mapped columns missing.
+*/
+func (mapping Mapping) Compare(header []string) ([]string, []string) {
+	unmapped := []string{}
+	missing := []string{}
+	isMapped := map[string]bool{}
+	for _, column := range mapping.Columns {
+		isMapped[column.Name] = true
+		if len(column.Attribute) > 0 && !slices.Contains(header, column.Name) {
+			missing = append(missing, column.Name)
+		}
+	}
+	for _, name := range header {
+		if !isMapped[name] {
+			unmapped = append(unmapped, name)
+		}
+	}
+	return unmapped, missing
+}
+
+/*
+The Validate method checks that the Mapping gives every record a data source,
+and maps each attribute from one column at most.
+
+Output
+  - ErrMissingDataSource or ErrDuplicateAttribute, if not.
+*/
+func (mapping Mapping) Validate() error {
+	columns := map[string]string{}
+	for _, column := range mapping.Columns {
+		attribute := strings.TrimSpace(column.Attribute)
+		if len(attribute) == 0 {
+			continue
+		}
+		if name, isFound := columns[attribute]; isFound {
+			return fmt.Errorf("%w: %s from %q and %q", ErrDuplicateAttribute, attribute, name, column.Name)
+		}
+		columns[attribute] = column.Name
+	}
+	if _, isFound := columns["DATA_SOURCE"]; !isFound && len(strings.TrimSpace(mapping.DataSource)) == 0 {
+		return ErrMissingDataSource
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Analyze function counts the records of a file of JSON Lines, by data source and by attribute,
+without loading them.
+
+Input
+  - filename: The file of JSON Lines.
+  - samples: The number of records to return as examples, from the start of the file.
+
+Output
+  - The counts.  Lines that are not records with a DATA_SOURCE and RECORD_ID are counted as Invalid.
+*/
+func Analyze(filename string, samples int) (Analysis, error) {
+	result := Analysis{
+		Attributes:  []Count{},
+		DataSources: []Count{},
+		Samples:     []json.RawMessage{},
+	}
+	attributes := map[string]int64{}
+	dataSources := map[string]int64{}
+	err := forEachLine(filename, func(number int64, text string) error {
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			return nil
+		}
+		record, err := decodeRecord(text)
+		if err != nil {
+			result.Invalid++
+			return nil
+		}
+		for attribute := range record {
+			attributes[attribute]++
+		}
+		dataSource, _, err := getRecordKey(record)
+		if err != nil {
+			result.Invalid++
+			return nil
+		}
+		result.Records++
+		dataSources[strings.ToUpper(dataSource)]++
+		if len(result.Samples) < samples {
+			result.Samples = append(result.Samples, json.RawMessage(text))
+		}
+		return nil
+	})
+	result.Attributes = getCounts(attributes)
+	result.DataSources = getCounts(dataSources)
+	return result, err
+}
+
+/*
+The CSVComma function returns the field delimiter of a file named *.csv or *.tsv.
+
+Input
+  - filename: The name of the file.
+
+Output
+  - ',' for *.csv, '\t' for *.tsv, or 0 for any other file.
+*/
+func CSVComma(filename string) rune {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ','
+	case ".tsv":
+		return '\t'
+	default:
+		return 0
+	}
+}
+
+/*
+The ConvertCSV function writes each row of a CSV file as a JSON line.
+Without a mapping, the row is keyed by the names in the header row; with one, as the mapping says.
+Empty values are left out, and rows with no values are skipped.
+
+Input
+  - input: The CSV file.
+  - output: Where the JSON lines are written.
+  - comma: The field delimiter.  Example: ',' or '\t'.
+  - mapping: How columns map to Senzing attributes.  Optional.
+
+Output
+  - The number of rows written.  ErrEmptyCSV if there is no header row.
+*/
+func ConvertCSV(input io.Reader, output io.Writer, comma rune, mapping *Mapping) (int64, error) {
+	var result int64
+	reader := newCSVReader(input, comma)
+	header, err := readCSVHeader(reader)
+	if err != nil {
+		return result, err
+	}
+	attributes := header
+	if mapping != nil {
+		err = mapping.Validate()
+		if err != nil {
+			return result, err
+		}
+		attributes = mapping.getAttributes(header)
+	}
+	isDataSourceMapped := slices.Contains(attributes, "DATA_SOURCE")
+	isRecordIDMapped := slices.Contains(attributes, "RECORD_ID")
+	writer := bufio.NewWriter(output)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return result, writer.Flush()
+		}
+		if err != nil {
+			return result, err
+		}
+		constants := []string{}
+		if mapping != nil && !isDataSourceMapped {
+			constants = append(constants, "DATA_SOURCE", mapping.DataSource)
+		}
+		if mapping != nil && !isRecordIDMapped {
+			lineNumber, _ := reader.FieldPos(0)
+			constants = append(constants, "RECORD_ID", strconv.Itoa(lineNumber))
+		}
+		line, err := getCSVRecord(attributes, row, constants...)
+		if err != nil {
+			return result, err
+		}
+		if line == nil {
+			continue
+		}
+		_, err = writer.Write(append(line, '\n'))
+		if err != nil {
+			return result, err
+		}
+		result++
+	}
+}
+
+/*
+The ReadCSVHeader function reads the column names in the header row of a CSV file.
+
+Input
+  - input: The CSV file.
+  - comma: The field delimiter.  Example: ',' or '\t'.
+
+Output
+  - The column names.  ErrEmptyCSV if there is no header row.
+*/
+func ReadCSVHeader(input io.Reader, comma rune) ([]string, error) {
+	return readCSVHeader(newCSVReader(input, comma))
+}
+
+/*
+The ReadMapping function reads a Mapping saved by WriteMapping, or written by hand.
+
+Input
+  - filename: The mapping file, in JSON.
+
+Output
+  - The mapping, checked by Mapping.Validate.
+*/
+func ReadMapping(filename string) (Mapping, error) {
+	result := Mapping{}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return result, fmt.Errorf("mapping file %s: %w", filename, err)
+	}
+	err = result.Validate()
+	if err != nil {
+		return result, fmt.Errorf("mapping file %s: %w", filename, err)
+	}
+	return result, nil
+}
+
+/*
+The Summarize function finds the entities the records of a file resolved into, once loaded.
+
+Input
+  - ctx: A context to control lifecycle.
+  - engine: The Senzing engine the records were loaded with.
+  - filename: The file of JSON Lines that was loaded.
+  - failuresFile: The failures file of the load, so records that failed are left out.  Optional.
+
+Output
+  - The number of records found, of distinct entities holding them, and of those entities holding more than one.
+*/
+func Summarize(ctx context.Context, engine senzing.SzEngine, filename string, failuresFile string) (Summary, error) {
+	result := Summary{}
+	failedLines := map[int64]bool{}
+	if len(failuresFile) > 0 {
+		err := forEachLine(failuresFile, func(number int64, text string) error {
+			failure := Failure{}
+			if json.Unmarshal([]byte(text), &failure) == nil {
+				failedLines[failure.Line] = true
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+	}
+	entityRecords := map[int64]int64{}
+	err := forEachLine(filename, func(number int64, text string) error {
+		if failedLines[number] || len(strings.TrimSpace(text)) == 0 {
+			return nil
+		}
+		dataSource, recordID, err := parseRecord(text)
+		if err != nil {
+			return nil
+		}
+		entity, err := engine.GetEntityByRecordID(ctx, dataSource, recordID, senzing.SzNoFlags)
+		if err != nil {
+			return err
+		}
+		entityID, err := parseEntityID(entity)
+		if err != nil {
+			return err
+		}
+		result.Records++
+		entityRecords[entityID]++
+		return nil
+	})
+	result.Entities = int64(len(entityRecords))
+	for _, records := range entityRecords {
+		if records > 1 {
+			result.ResolvedEntities++
+		}
+	}
+	return result, err
+}
+
+/*
+The SuggestMapping function guesses the Senzing attribute of each column of a CSV file from its name.
+Names are compared ignoring case and punctuation, so "First Name" and "first_name" both map to NAME_FIRST.
+Columns named after no known attribute map to their name, upper-cased, which Senzing keeps as is.
+Unless a column is named after RECORD_ID, the first such column named *_ID, like "Customer ID", maps to it.
+Unless a column maps to DATA_SOURCE, the data source is named after the file.
+
+Input
+  - filename: The name of the CSV file.
+  - header: The column names in its header row.
+
+Output
+  - The suggested mapping.  If more than one column maps to an attribute, only the first keeps it.
+*/
+func SuggestMapping(filename string, header []string) Mapping {
+	result := Mapping{Columns: make([]Column, 0, len(header))}
+	isMapped := map[string]bool{}
+	for _, name := range header {
+		attribute := normalizeColumnName(name)
+		if synonym, isFound := attributeSynonyms[attribute]; isFound {
+			attribute = synonym
+		}
+		if isMapped[attribute] {
+			attribute = ""
+		}
+		if len(attribute) > 0 {
+			isMapped[attribute] = true
+		}
+		result.Columns = append(result.Columns, Column{Attribute: attribute, Name: name})
+	}
+	for index, column := range result.Columns {
+		if isMapped["RECORD_ID"] {
+			break
+		}
+		if strings.HasSuffix(column.Attribute, "_ID") && !slices.Contains(Attributes, column.Attribute) {
+			result.Columns[index].Attribute = "RECORD_ID"
+			isMapped["RECORD_ID"] = true
+		}
+	}
+	if !isMapped["DATA_SOURCE"] {
+		result.DataSource = normalizeColumnName(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)))
+	}
+	return result
+}
+
+/*
+The WriteMapping function saves a Mapping as indented JSON, to be edited and read by ReadMapping.
+
+Input
+  - filename: The mapping file.  Replaced if it exists.
+  - mapping: The mapping.
+*/
+func WriteMapping(filename string, mapping Mapping) error {
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o600)
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Add the records after startLine, in parallel, until every line is read or ctx is cancelled.
+func (loader *BasicLoader) loadRecords(ctx context.Context, startLine int64, start time.Time, result *Result) error {
+	engine, err := loader.Factory.CreateEngine(ctx)
+	if err != nil {
+		return err
+	}
+	failures, err := loader.openFailures(startLine)
+	if err != nil {
+		return err
+	}
+	defer failures.Close()
+	encoder := json.NewEncoder(failures)
+
+	// Read lines until ctx is cancelled.  Records already being added are finished regardless.
+
+	lines := make(chan inputLine, loader.getThreads())
+	var readErr error
+	go func() {
+		defer close(lines)
+		readErr = forEachLine(loader.InputFile, func(number int64, text string) error {
+			if number <= startLine {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			select {
+			case lines <- inputLine{number: number, text: text}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	outcomes := make(chan outcome, loader.getThreads())
+	addCtx := context.WithoutCancel(ctx)
+	var workers sync.WaitGroup
+	for index := 0; index < loader.getThreads(); index++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for line := range lines {
+				outcomes <- addRecord(addCtx, engine, line)
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(outcomes)
+	}()
+
+	// Count outcomes.  Lines finish out of order; the checkpoint is the last line before any unfinished one.
+
+	checkpoint := startLine
+	finished := map[int64]bool{}
+	var writeErr error
+	ticker := time.NewTicker(loader.getProgressInterval())
+	defer ticker.Stop()
+collect:
+	for {
+		select {
+		case lineOutcome, isOpen := <-outcomes:
+			if !isOpen {
+				break collect
+			}
+			switch {
+			case lineOutcome.failure != nil:
+				result.Failed++
+				if failures != nil && writeErr == nil {
+					writeErr = encoder.Encode(lineOutcome.failure)
+				}
+			case !lineOutcome.isBlank:
+				result.Loaded++
+			}
+			finished[lineOutcome.number] = true
+			for finished[checkpoint+1] {
+				delete(finished, checkpoint+1)
+				checkpoint++
+			}
+		case <-ticker.C:
+			loader.reportProgress(result, start)
+			if writeErr == nil {
+				writeErr = loader.writeCheckpoint(checkpoint)
+			}
+		}
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	if readErr != nil {
+		err = loader.writeCheckpoint(checkpoint)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil && errors.Is(readErr, ctx.Err()) {
+			return fmt.Errorf("%w after line %d of %s", ErrInterrupted, checkpoint, loader.InputFile)
+		}
+		return readErr
+	}
+	return loader.removeCheckpoint()
+}
+
+// Open FailuresFile, or return nil if it is not set.  Closing a nil *os.File is harmless.
+// Resuming after startLine, the failures of the lines up to it are kept; those after it are added again.
+func (loader *BasicLoader) openFailures(startLine int64) (*os.File, error) {
+	if len(loader.FailuresFile) == 0 {
+		return nil, nil
+	}
+	if !loader.Resume {
+		return os.OpenFile(loader.FailuresFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	}
+	err := loader.keepFailures(startLine)
+	if err != nil {
+		return nil, err
+	}
+	return os.OpenFile(loader.FailuresFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+}
+
+// Rewrite FailuresFile, if it exists, with only the failures of the lines up to startLine.
+// A failure left half written by an interruption is dropped.
+func (loader *BasicLoader) keepFailures(startLine int64) error {
+	kept := []byte{}
+	err := forEachLine(loader.FailuresFile, func(number int64, text string) error {
+		_ = number
+		failure := Failure{}
+		if json.Unmarshal([]byte(text), &failure) == nil && failure.Line > 0 && failure.Line <= startLine {
+			kept = append(append(kept, text...), '\n')
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	temporaryFile := loader.FailuresFile + ".tmp"
+	err = os.WriteFile(temporaryFile, kept, 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryFile, loader.FailuresFile)
+}
+
+/*
+Add the data sources missing from the default configuration to a new configuration,
+make it the default, and reinitialize the Senzing objects of the factory to use it.
+*/
+func (loader *BasicLoader) registerDataSources(ctx context.Context, dataSources []string) ([]string, error) {
+	result := []string{}
+	if len(dataSources) == 0 {
+		return result, nil
+	}
+	configManager, err := loader.Factory.CreateConfigManager(ctx)
+	if err != nil {
+		return result, err
+	}
+	config, err := loader.Factory.CreateConfig(ctx)
+	if err != nil {
+		return result, err
+	}
+	configID, err := configManager.GetDefaultConfigID(ctx)
+	if err != nil {
+		return result, err
+	}
+	configDefinition, err := configManager.GetConfig(ctx, configID)
+	if err != nil {
+		return result, err
+	}
+	configHandle, err := config.ImportConfig(ctx, configDefinition)
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		_ = config.CloseConfig(ctx, configHandle)
+	}()
+	registered, err := config.GetDataSources(ctx, configHandle)
+	if err != nil {
+		return result, err
+	}
+	isRegistered, err := parseDataSources(registered)
+	if err != nil {
+		return result, fmt.Errorf("data sources of configuration %d: %w", configID, err)
+	}
+	for _, dataSource := range dataSources {
+		if isRegistered[dataSource] {
+			continue
+		}
+		_, err = config.AddDataSource(ctx, configHandle, dataSource)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, dataSource)
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+	configDefinition, err = config.ExportConfig(ctx, configHandle)
+	if err != nil {
+		return nil, err
+	}
+	newConfigID, err := configManager.AddConfig(ctx, configDefinition, "playground load: added "+strings.Join(result, ", "))
+	if err != nil {
+		return nil, err
+	}
+	err = configManager.ReplaceDefaultConfigID(ctx, configID, newConfigID)
+	if err != nil {
+		return nil, err
+	}
+	err = loader.Factory.Reinitialize(ctx, newConfigID)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// --- Checkpoint -------------------------------------------------------------
+
+// The last line of InputFile already loaded or failed, or 0 unless resuming.
+func (loader *BasicLoader) readCheckpoint() (int64, error) {
+	if !loader.Resume || len(loader.CheckpointFile) == 0 {
+		return 0, nil
+	}
+	contents, err := os.ReadFile(loader.CheckpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		loader.printf("No checkpoint %s.  Loading from the first line.\n", loader.CheckpointFile)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	checkpoint := Checkpoint{}
+	err = json.Unmarshal(contents, &checkpoint)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", loader.CheckpointFile, err)
+	}
+	inputFile, err := filepath.Abs(loader.InputFile)
+	if err != nil {
+		return 0, err
+	}
+	if checkpoint.File != inputFile {
+		return 0, fmt.Errorf("%w: %s is for %s", ErrCheckpointMismatch, loader.CheckpointFile, checkpoint.File)
+	}
+	return checkpoint.Line, nil
+}
+
+func (loader *BasicLoader) removeCheckpoint() error {
+	if len(loader.CheckpointFile) == 0 {
+		return nil
+	}
+	err := os.Remove(loader.CheckpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Replace CheckpointFile, if set, so it is never left half written.
+func (loader *BasicLoader) writeCheckpoint(line int64) error {
+	if len(loader.CheckpointFile) == 0 {
+		return nil
+	}
+	inputFile, err := filepath.Abs(loader.InputFile)
+	if err != nil {
+		return err
+	}
+	contents, err := json.Marshal(Checkpoint{File: inputFile, Line: line, Time: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	temporaryFile := loader.CheckpointFile + ".tmp"
+	err = os.WriteFile(temporaryFile, append(contents, '\n'), 0o600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryFile, loader.CheckpointFile)
+}
+
+// --- Output -----------------------------------------------------------------
+
+func (loader *BasicLoader) getProgressInterval() time.Duration {
+	if loader.ProgressInterval <= 0 {
+		return DefaultProgressInterval
+	}
+	return loader.ProgressInterval
+}
+
+func (loader *BasicLoader) getThreads() int {
+	if loader.Threads <= 0 {
+		return DefaultThreads
+	}
+	return loader.Threads
+}
+
+func (loader *BasicLoader) printf(format string, arguments ...any) {
+	if loader.Output != nil {
+		_, _ = fmt.Fprintf(loader.Output, format, arguments...)
+	}
+}
+
+// Report the records done, and the records added or failed per second since start.
+func (loader *BasicLoader) reportProgress(result *Result, start time.Time) {
+	result.Elapsed = time.Since(start)
+	done := result.Loaded + result.Failed
+	rate := float64(done) / result.Elapsed.Seconds()
+	loader.printf("%d of %d records: %d loaded, %d failed, %d skipped.  %.1f records/second.\n",
+		done+result.Skipped, result.Total, result.Loaded, result.Failed, result.Skipped, rate)
+	if loader.Progress != nil {
+		loader.Progress(*result)
+	}
+}
+
+// --- Mapping ----------------------------------------------------------------
+
+// The attribute each column of a header row maps to, or "" if it is left out.
+func (mapping Mapping) getAttributes(header []string) []string {
+	attributes := map[string]string{}
+	for _, column := range mapping.Columns {
+		attributes[column.Name] = strings.TrimSpace(column.Attribute)
+	}
+	result := make([]string, len(header))
+	for index, name := range header {
+		result[index] = attributes[name]
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Add the record on a line, returning the Failure if it could not be added.
+func addRecord(ctx context.Context, engine senzing.SzEngine, line inputLine) outcome {
+	text := strings.TrimSpace(line.text)
+	if len(text) == 0 {
+		return outcome{isBlank: true, number: line.number}
+	}
+	dataSource, recordID, err := parseRecord(text)
+	if err == nil {
+		_, err = engine.AddRecord(ctx, dataSource, recordID, text, senzing.SzWithoutInfo)
+	}
+	if err != nil {
+		return outcome{
+			failure: &Failure{
+				DataSource: dataSource,
+				Error:      err.Error(),
+				Line:       line.number,
+				Record:     text,
+				RecordID:   recordID,
+			},
+			number: line.number,
+		}
+	}
+	return outcome{number: line.number}
+}
+
+// Append "name":value to a JSON object being written.
+func appendJSONField(object []byte, name string, value string) ([]byte, error) {
+	encodedName, err := json.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(object) > 1 {
+		object = append(object, ',')
+	}
+	return append(append(append(object, encodedName...), ':'), encodedValue...), nil
+}
+
+// A line of JSON Lines as a JSON object, keeping numbers as written.
+func decodeRecord(text string) (map[string]any, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	result := map[string]any{}
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	return result, nil
+}
+
+// Call fn with each line of a file, without its line ending, until fn returns an error.
+func forEachLine(filename string, fn func(number int64, text string) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for number := int64(1); ; number++ {
+		text, err := reader.ReadString('\n')
+		if len(text) > 0 {
+			fnErr := fn(number, strings.TrimRight(text, "\r\n"))
+			if fnErr != nil {
+				return fnErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Counts by name, most frequent first, then by name.
+func getCounts(counts map[string]int64) []Count {
+	result := []Count{}
+	for name, count := range counts {
+		result = append(result, Count{Count: count, Name: name})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// A JSON object of the constants, pairs of name and value, then the non-empty values of a CSV row
+// keyed by the attributes of their columns, or nil if every value is empty or left out.
+func getCSVRecord(attributes []string, row []string, constants ...string) ([]byte, error) {
+	var err error
+	result := []byte{'{'}
+	for index := 0; index+1 < len(constants); index += 2 {
+		result, err = appendJSONField(result, constants[index], constants[index+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	hasValue := false
+	for index, value := range row {
+		if index >= len(attributes) || len(attributes[index]) == 0 || len(strings.TrimSpace(value)) == 0 {
+			continue
+		}
+		result, err = appendJSONField(result, attributes[index], value)
+		if err != nil {
+			return nil, err
+		}
+		hasValue = true
+	}
+	if !hasValue {
+		return nil, nil
+	}
+	return append(result, '}'), nil
+}
+
+// The DATA_SOURCE and RECORD_ID of a record.  The DATA_SOURCE is returned, if found, even when the record is invalid.
+func getRecordKey(record map[string]any) (string, string, error) {
+	dataSource := toString(record["DATA_SOURCE"])
+	recordID := toString(record["RECORD_ID"])
+	if len(dataSource) == 0 {
+		return dataSource, recordID, fmt.Errorf("%w: no DATA_SOURCE", ErrInvalidRecord)
+	}
+	if len(recordID) == 0 {
+		return dataSource, recordID, fmt.Errorf("%w: no RECORD_ID", ErrInvalidRecord)
+	}
+	return dataSource, recordID, nil
+}
+
+// A CSV reader that allows rows of any length, and ignores spaces before values.
+func newCSVReader(input io.Reader, comma rune) *csv.Reader {
+	result := csv.NewReader(input)
+	result.Comma = comma
+	result.FieldsPerRecord = -1
+	result.TrimLeadingSpace = true
+	return result
+}
+
+// A column name in upper case, with each run of other than letters and digits replaced by "_".
+func normalizeColumnName(name string) string {
+	var result strings.Builder
+	isSeparated := false
+	for _, character := range strings.ToUpper(strings.TrimSpace(name)) {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) {
+			if isSeparated && result.Len() > 0 {
+				result.WriteByte('_')
+			}
+			result.WriteRune(character)
+			isSeparated = false
+			continue
+		}
+		isSeparated = true
+	}
+	return result.String()
+}
+
+// The codes of the data sources in the output of SzConfig.GetDataSources.
+func parseDataSources(dataSources string) (map[string]bool, error) {
+	parsed := struct {
+		DataSources []struct {
+			Code string `json:"DSRC_CODE"`
+		} `json:"DATA_SOURCES"`
+	}{}
+	err := json.Unmarshal([]byte(dataSources), &parsed)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]bool{}
+	for _, dataSource := range parsed.DataSources {
+		result[strings.ToUpper(dataSource.Code)] = true
+	}
+	return result, nil
+}
+
+// The ENTITY_ID in the output of SzEngine.GetEntityByRecordID.
+func parseEntityID(entity string) (int64, error) {
+	parsed := struct {
+		ResolvedEntity struct {
+			EntityID int64 `json:"ENTITY_ID"`
+		} `json:"RESOLVED_ENTITY"`
+	}{}
+	err := json.Unmarshal([]byte(entity), &parsed)
+	return parsed.ResolvedEntity.EntityID, err
+}
+
+// The DATA_SOURCE and RECORD_ID of a line.  A numeric RECORD_ID is returned as written.
+func parseRecord(text string) (string, string, error) {
+	record, err := decodeRecord(text)
+	if err != nil {
+		return "", "", err
+	}
+	return getRecordKey(record)
+}
+
+// The column names in the header row, without a byte order mark.
+func readCSVHeader(reader *csv.Reader) ([]string, error) {
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyCSV
+	}
+	if err != nil {
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	return header, nil
+}
+
+// Find the data sources named in a file, in upper case, and count its records, those up to startLine as skipped.
+func scanFile(filename string, startLine int64, result *Result) ([]string, error) {
+	isNamed := map[string]bool{}
+	err := forEachLine(filename, func(number int64, text string) error {
+		if len(strings.TrimSpace(text)) == 0 {
+			return nil
+		}
+		result.Total++
+		if number <= startLine {
+			result.Skipped++
+		}
+		dataSource, _, _ := parseRecord(text)
+		if len(dataSource) > 0 {
+			isNamed[strings.ToUpper(dataSource)] = true
+		}
+		return nil
+	})
+	dataSources := []string{}
+	for dataSource := range isNamed {
+		dataSources = append(dataSources, dataSource)
+	}
+	sort.Strings(dataSources)
+	return dataSources, err
+}
+
+func toString(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case json.Number:
+		return typedValue.String()
+	default:
+		return ""
+	}
+}
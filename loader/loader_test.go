@@ -0,0 +1,545 @@
+package loader
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/sz-sdk-go/senzing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTestAddRecord = errors.New("test: record rejected")
+	errTestGetEntity = errors.New("test: record not found")
+)
+
+const testRecords = `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001", "NAME_FULL": "Robert Smith"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": 1002, "NAME_FULL": "Bob Smith"}
+
+{"DATA_SOURCE": "watchlist", "RECORD_ID": "2001", "NAME_FULL": "Bobby Smith"}
+not JSON
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "REJECT"}
+{"DATA_SOURCE": "REFERENCE", "NAME_FULL": "No Record ID"}
+{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1003", "NAME_FULL": "Robbie Smith"}
+`
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestBasicLoader_Load(test *testing.T) {
+	ctx := context.TODO()
+	factory := newTestFactory("CUSTOMERS")
+	loader := getTestObject(test, factory)
+	output := &strings.Builder{}
+	loader.Output = output
+	progress := []Result{}
+	loader.Progress = func(result Result) {
+		progress = append(progress, result)
+	}
+	result, err := loader.Load(ctx)
+	require.NoError(test, err)
+	require.NotEmpty(test, progress)
+	assert.Equal(test, result, progress[len(progress)-1], "the last progress is the result")
+	assert.Equal(test, []string{"REFERENCE", "WATCHLIST"}, result.DataSources)
+	assert.Equal(test, int64(4), result.Loaded)
+	assert.Equal(test, int64(3), result.Failed)
+	assert.Equal(test, int64(0), result.Skipped)
+	assert.Equal(test, int64(7), result.Total)
+	assert.Equal(test, []string{"1001", "1002", "1003", "2001"}, factory.engine.getRecordIDs())
+	assert.Equal(test, int64(2), factory.reinitializedConfigID, "the new configuration is the default")
+	assert.Contains(test, output.String(), "Registered data sources: REFERENCE, WATCHLIST.")
+	assert.Contains(test, output.String(), "7 of 7 records: 4 loaded, 3 failed, 0 skipped.")
+	assert.NoFileExists(test, loader.CheckpointFile, "the checkpoint is removed once every line is loaded")
+
+	failures := readFailures(test, loader.FailuresFile)
+	require.Len(test, failures, 3)
+	assert.Equal(test, int64(5), failures[0].Line)
+	assert.Contains(test, failures[0].Error, ErrInvalidRecord.Error())
+	assert.Equal(test, "not JSON", failures[0].Record)
+	assert.Equal(test, "REJECT", failures[1].RecordID)
+	assert.Equal(test, errTestAddRecord.Error(), failures[1].Error)
+	assert.Equal(test, "REFERENCE", failures[2].DataSource)
+	assert.Contains(test, failures[2].Error, "no RECORD_ID")
+}
+
+func TestBasicLoader_Load_registered(test *testing.T) {
+	factory := newTestFactory("CUSTOMERS", "REFERENCE", "WATCHLIST")
+	loader := getTestObject(test, factory)
+	result, err := loader.Load(context.TODO())
+	require.NoError(test, err)
+	assert.Empty(test, result.DataSources)
+	assert.Equal(test, int64(0), factory.reinitializedConfigID, "the configuration is unchanged")
+}
+
+func TestBasicLoader_Load_resume(test *testing.T) {
+	factory := newTestFactory("CUSTOMERS", "REFERENCE", "WATCHLIST")
+	loader := getTestObject(test, factory)
+	loader.Resume = true
+	require.NoError(test, loader.writeCheckpoint(5))
+	earlierFailures := `{"error": "earlier", "line": 5, "record": "not JSON"}` + "\n" +
+		`{"error": "after the checkpoint", "line": 6, "record": "{}"}` + "\n" +
+		`{"error": "half wri`
+	require.NoError(test, os.WriteFile(loader.FailuresFile, []byte(earlierFailures), 0o600))
+	result, err := loader.Load(context.TODO())
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), result.Loaded)
+	assert.Equal(test, int64(2), result.Failed)
+	assert.Equal(test, int64(4), result.Skipped)
+	assert.Equal(test, []string{"1003"}, factory.engine.getRecordIDs())
+	failures := readFailures(test, loader.FailuresFile)
+	require.Len(test, failures, 3, "failures up to the checkpoint are kept, and those after it written again")
+	assert.Equal(test, "earlier", failures[0].Error)
+	assert.ElementsMatch(test, []int64{5, 6, 7}, []int64{failures[0].Line, failures[1].Line, failures[2].Line})
+
+	loader.InputFile = filepath.Join(test.TempDir(), "other.jsonl")
+	require.NoError(test, os.WriteFile(loader.InputFile, []byte(testRecords), 0o600))
+	require.NoError(test, loader.writeCheckpoint(1))
+	loader.InputFile = filepath.Join(filepath.Dir(loader.CheckpointFile), "records.jsonl")
+	_, err = loader.Load(context.TODO())
+	require.ErrorIs(test, err, ErrCheckpointMismatch)
+}
+
+func TestBasicLoader_Load_resumeWithoutCheckpoint(test *testing.T) {
+	factory := newTestFactory("CUSTOMERS", "REFERENCE", "WATCHLIST")
+	loader := getTestObject(test, factory)
+	loader.Resume = true
+	output := &strings.Builder{}
+	loader.Output = output
+	result, err := loader.Load(context.TODO())
+	require.NoError(test, err)
+	assert.Equal(test, int64(4), result.Loaded)
+	assert.Contains(test, output.String(), "Loading from the first line.")
+}
+
+func TestBasicLoader_Load_interrupted(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	factory := newTestFactory("CUSTOMERS", "REFERENCE", "WATCHLIST")
+	factory.engine.onAdd = func(recordID string) {
+		if recordID == "2001" {
+			cancel()
+		}
+	}
+	loader := getTestObject(test, factory)
+	loader.Threads = 1
+	result, err := loader.Load(ctx)
+	require.ErrorIs(test, err, ErrInterrupted)
+	assert.Less(test, result.Loaded+result.Failed, result.Total)
+
+	contents, err := os.ReadFile(loader.CheckpointFile)
+	require.NoError(test, err)
+	checkpoint := Checkpoint{}
+	require.NoError(test, json.Unmarshal(contents, &checkpoint))
+	assert.GreaterOrEqual(test, checkpoint.Line, int64(4), "lines up to the one being added when interrupted are done")
+
+	loader.Resume = true
+	factory.engine.onAdd = nil
+	result, err = loader.Load(context.TODO())
+	require.NoError(test, err)
+	assert.Equal(test, int64(7), result.Loaded+result.Failed+result.Skipped)
+	assert.Equal(test, []string{"1001", "1002", "1003", "2001"}, factory.engine.getRecordIDs())
+}
+
+func TestBasicLoader_Load_missingFile(test *testing.T) {
+	loader := &BasicLoader{}
+	_, err := loader.Load(context.TODO())
+	require.ErrorIs(test, err, ErrMissingFile)
+	loader.InputFile = filepath.Join(test.TempDir(), "missing.jsonl")
+	_, err = loader.Load(context.TODO())
+	require.ErrorIs(test, err, os.ErrNotExist)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestAnalyze(test *testing.T) {
+	loader := getTestObject(test, nil)
+	analysis, err := Analyze(loader.InputFile, 2)
+	require.NoError(test, err)
+	assert.Equal(test, int64(5), analysis.Records)
+	assert.Equal(test, int64(2), analysis.Invalid)
+	assert.Equal(test, []Count{{Count: 4, Name: "CUSTOMERS"}, {Count: 1, Name: "WATCHLIST"}}, analysis.DataSources)
+	assert.Equal(test, Count{Count: 6, Name: "DATA_SOURCE"}, analysis.Attributes[0])
+	assert.Contains(test, analysis.Attributes, Count{Count: 5, Name: "NAME_FULL"})
+	require.Len(test, analysis.Samples, 2)
+	assert.JSONEq(test, `{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": 1002, "NAME_FULL": "Bob Smith"}`, string(analysis.Samples[1]))
+	_, err = Analyze(filepath.Join(test.TempDir(), "missing.jsonl"), 2)
+	require.ErrorIs(test, err, os.ErrNotExist)
+}
+
+func TestConvertCSV(test *testing.T) {
+	input := "\ufeffDATA_SOURCE,RECORD_ID,NAME_FULL,PHONE_NUMBER\n" +
+		"CUSTOMERS,1001,\"Smith, Robert\",\n" +
+		",,,\n" +
+		"CUSTOMERS,1002,Bob Smith,555-0100,extra\n"
+	output := &strings.Builder{}
+	rows, err := ConvertCSV(strings.NewReader(input), output, ',', nil)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), rows)
+	assert.Equal(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","NAME_FULL":"Smith, Robert"}`+"\n"+
+		`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1002","NAME_FULL":"Bob Smith","PHONE_NUMBER":"555-0100"}`+"\n", output.String())
+
+	output.Reset()
+	rows, err = ConvertCSV(strings.NewReader("DATA_SOURCE\tRECORD_ID\nTEST\t1\n"), output, '\t', nil)
+	require.NoError(test, err)
+	assert.Equal(test, int64(1), rows)
+	assert.Equal(test, `{"DATA_SOURCE":"TEST","RECORD_ID":"1"}`+"\n", output.String())
+
+	_, err = ConvertCSV(strings.NewReader(""), output, ',', nil)
+	require.ErrorIs(test, err, ErrEmptyCSV)
+	_, err = ConvertCSV(strings.NewReader("A,B\n\"unterminated\n"), output, ',', nil)
+	require.Error(test, err)
+}
+
+func TestConvertCSV_mapping(test *testing.T) {
+	input := "Customer ID,First Name,Surname,Notes\n" +
+		"1001,Robert,Smith,VIP\n" +
+		",,,\n" +
+		",Bob,Smith,\n"
+	mapping := &Mapping{
+		Columns: []Column{
+			{Attribute: "NAME_FIRST", Name: "First Name"},
+			{Attribute: "NAME_LAST", Name: "Surname"},
+			{Attribute: "", Name: "Notes"},
+		},
+		DataSource: "CUSTOMERS",
+	}
+	output := &strings.Builder{}
+	rows, err := ConvertCSV(strings.NewReader(input), output, ',', mapping)
+	require.NoError(test, err)
+	assert.Equal(test, int64(2), rows)
+	assert.Equal(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"2","NAME_FIRST":"Robert","NAME_LAST":"Smith"}`+"\n"+
+		`{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"4","NAME_FIRST":"Bob","NAME_LAST":"Smith"}`+"\n", output.String(), "RECORD_ID is the line number")
+
+	mapping.Columns = append(mapping.Columns, Column{Attribute: "RECORD_ID", Name: "Customer ID"})
+	output.Reset()
+	_, err = ConvertCSV(strings.NewReader(input), output, ',', mapping)
+	require.NoError(test, err)
+	assert.Equal(test, `{"DATA_SOURCE":"CUSTOMERS","RECORD_ID":"1001","NAME_FIRST":"Robert","NAME_LAST":"Smith"}`+"\n"+
+		`{"DATA_SOURCE":"CUSTOMERS","NAME_FIRST":"Bob","NAME_LAST":"Smith"}`+"\n", output.String())
+
+	mapping.Columns = append(mapping.Columns, Column{Attribute: "NAME_LAST", Name: "Notes"})
+	_, err = ConvertCSV(strings.NewReader(input), output, ',', mapping)
+	require.ErrorIs(test, err, ErrDuplicateAttribute)
+}
+
+func TestCSVComma(test *testing.T) {
+	assert.Equal(test, ',', CSVComma("customers.csv"))
+	assert.Equal(test, '\t', CSVComma("CUSTOMERS.TSV"))
+	assert.Equal(test, rune(0), CSVComma("customers.jsonl"))
+}
+
+func TestMapping_Compare(test *testing.T) {
+	mapping := Mapping{Columns: []Column{{Attribute: "NAME_FULL", Name: "Name"}, {Attribute: "PHONE_NUMBER", Name: "Phone"}, {Name: "Notes"}}}
+	unmapped, missing := mapping.Compare([]string{"Name", "Email", "Notes"})
+	assert.Equal(test, []string{"Email"}, unmapped)
+	assert.Equal(test, []string{"Phone"}, missing)
+}
+
+func TestMapping_Validate(test *testing.T) {
+	require.NoError(test, Mapping{DataSource: "TEST"}.Validate())
+	require.NoError(test, Mapping{Columns: []Column{{Attribute: "DATA_SOURCE", Name: "Source"}}}.Validate())
+	require.ErrorIs(test, Mapping{}.Validate(), ErrMissingDataSource)
+	err := Mapping{Columns: []Column{{Attribute: "NAME_FULL", Name: "Name"}, {Attribute: " NAME_FULL", Name: "Full name"}}, DataSource: "TEST"}.Validate()
+	require.ErrorIs(test, err, ErrDuplicateAttribute)
+	assert.Contains(test, err.Error(), `"Full name"`)
+}
+
+func TestReadCSVHeader(test *testing.T) {
+	header, err := ReadCSVHeader(strings.NewReader("\ufeffFirst Name\tLast Name\nRobert\tSmith\n"), '\t')
+	require.NoError(test, err)
+	assert.Equal(test, []string{"First Name", "Last Name"}, header)
+	_, err = ReadCSVHeader(strings.NewReader(""), ',')
+	require.ErrorIs(test, err, ErrEmptyCSV)
+}
+
+func TestSuggestMapping(test *testing.T) {
+	mapping := SuggestMapping("/data/my-customers.csv", []string{"ID", "First Name", "last_name", "E-Mail", "Zip Code", "phone", "Mobile", "Loyalty Tier", ""})
+	assert.Equal(test, "MY_CUSTOMERS", mapping.DataSource)
+	assert.Equal(test, []Column{
+		{Attribute: "RECORD_ID", Name: "ID"},
+		{Attribute: "NAME_FIRST", Name: "First Name"},
+		{Attribute: "NAME_LAST", Name: "last_name"},
+		{Attribute: "EMAIL_ADDRESS", Name: "E-Mail"},
+		{Attribute: "ADDR_POSTAL_CODE", Name: "Zip Code"},
+		{Attribute: "PHONE_NUMBER", Name: "phone"},
+		{Attribute: "", Name: "Mobile"},
+		{Attribute: "LOYALTY_TIER", Name: "Loyalty Tier"},
+		{Attribute: "", Name: ""},
+	}, mapping.Columns)
+	require.NoError(test, mapping.Validate())
+
+	mapping = SuggestMapping("customers.csv", []string{"DATA_SOURCE", "RECORD_ID"})
+	assert.Empty(test, mapping.DataSource, "a column maps to DATA_SOURCE")
+
+	mapping = SuggestMapping("customers.csv", []string{"Tax ID", "Customer ID", "Account ID"})
+	assert.Equal(test, []Column{
+		{Attribute: "TAX_ID_NUMBER", Name: "Tax ID"},
+		{Attribute: "RECORD_ID", Name: "Customer ID"},
+		{Attribute: "ACCOUNT_ID", Name: "Account ID"},
+	}, mapping.Columns)
+}
+
+func TestWriteMapping(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "customers.mapping.json")
+	mapping := Mapping{Columns: []Column{{Attribute: "NAME_FULL", Name: "Name"}}, DataSource: "CUSTOMERS"}
+	require.NoError(test, WriteMapping(filename, mapping))
+	actual, err := ReadMapping(filename)
+	require.NoError(test, err)
+	assert.Equal(test, mapping, actual)
+
+	require.NoError(test, os.WriteFile(filename, []byte(`{"columns": [{"name": "Name", "attribute": "NAME_FULL"}]}`), 0o600))
+	_, err = ReadMapping(filename)
+	require.ErrorIs(test, err, ErrMissingDataSource)
+	require.NoError(test, os.WriteFile(filename, []byte(`not JSON`), 0o600))
+	_, err = ReadMapping(filename)
+	require.Error(test, err)
+}
+
+func TestSummarize(test *testing.T) {
+	ctx := context.TODO()
+	factory := newTestFactory("CUSTOMERS")
+	loader := getTestObject(test, factory)
+	_, err := loader.Load(ctx)
+	require.NoError(test, err)
+	summary, err := Summarize(ctx, factory.engine, loader.InputFile, loader.FailuresFile)
+	require.NoError(test, err)
+	assert.Equal(test, Summary{Entities: 2, Records: 4, ResolvedEntities: 1}, summary)
+	summary, err = Summarize(ctx, factory.engine, loader.InputFile, "")
+	require.Error(test, err, "without the failures file, records that failed are looked up")
+	assert.Equal(test, int64(3), summary.Records)
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func TestParseRecord(test *testing.T) {
+	testCases := map[string]struct {
+		text       string
+		dataSource string
+		recordID   string
+		isValid    bool
+	}{
+		"string":      {text: `{"DATA_SOURCE": "TEST", "RECORD_ID": "1"}`, dataSource: "TEST", recordID: "1", isValid: true},
+		"number":      {text: `{"DATA_SOURCE": "TEST", "RECORD_ID": 12345678901234567890}`, dataSource: "TEST", recordID: "12345678901234567890", isValid: true},
+		"no ID":       {text: `{"DATA_SOURCE": "TEST"}`, dataSource: "TEST"},
+		"no source":   {text: `{"RECORD_ID": "1"}`, recordID: "1"},
+		"not JSON":    {text: `DATA_SOURCE,RECORD_ID`},
+		"not object":  {text: `["TEST", "1"]`},
+		"wrong types": {text: `{"DATA_SOURCE": ["TEST"], "RECORD_ID": true}`},
+	}
+	for name, testCase := range testCases {
+		dataSource, recordID, err := parseRecord(testCase.text)
+		assert.Equal(test, testCase.dataSource, dataSource, name)
+		assert.Equal(test, testCase.recordID, recordID, name)
+		if testCase.isValid {
+			require.NoError(test, err, name)
+		} else {
+			require.ErrorIs(test, err, ErrInvalidRecord, name)
+		}
+	}
+}
+
+func TestParseDataSources(test *testing.T) {
+	dataSources, err := parseDataSources(`{"DATA_SOURCES": [{"DSRC_ID": 1, "DSRC_CODE": "TEST"}, {"DSRC_ID": 2, "DSRC_CODE": "search"}]}`)
+	require.NoError(test, err)
+	assert.Equal(test, map[string]bool{"TEST": true, "SEARCH": true}, dataSources)
+	_, err = parseDataSources("")
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getTestObject(test *testing.T, factory senzing.SzAbstractFactory) *BasicLoader {
+	dir := test.TempDir()
+	loader := &BasicLoader{
+		CheckpointFile: filepath.Join(dir, "records.jsonl.checkpoint"),
+		Factory:        factory,
+		FailuresFile:   filepath.Join(dir, "records.jsonl.failures"),
+		InputFile:      filepath.Join(dir, "records.jsonl"),
+	}
+	require.NoError(test, os.WriteFile(loader.InputFile, []byte(testRecords), 0o600))
+	return loader
+}
+
+// The failures in a failures file, by line.  Records are added in parallel, so fail in any order.
+func readFailures(test *testing.T, filename string) []Failure {
+	file, err := os.Open(filename)
+	require.NoError(test, err)
+	defer file.Close()
+	result := []Failure{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		failure := Failure{}
+		require.NoError(test, json.Unmarshal(scanner.Bytes(), &failure))
+		result = append(result, failure)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Line < result[j].Line
+	})
+	return result
+}
+
+// --- testFactory ------------------------------------------------------------
+
+// testFactory holds a configuration of data sources in memory, and an engine that remembers the records added.
+type testFactory struct {
+	senzing.SzAbstractFactory
+	config                testConfig
+	configManager         testConfigManager
+	engine                *testEngine
+	reinitializedConfigID int64
+}
+
+type testConfig struct {
+	senzing.SzConfig
+	dataSources map[string]bool
+}
+
+type testConfigManager struct {
+	senzing.SzConfigManager
+	configs         map[int64]string
+	defaultConfigID int64
+}
+
+type testEngine struct {
+	senzing.SzEngine
+	entityIDs map[string]int64
+	mutex     sync.Mutex
+	onAdd     func(recordID string)
+	recordIDs []string
+}
+
+func newTestFactory(dataSources ...string) *testFactory {
+	definition, _ := json.Marshal(dataSources)
+	return &testFactory{
+		config: testConfig{dataSources: map[string]bool{}},
+		configManager: testConfigManager{
+			configs:         map[int64]string{1: string(definition)},
+			defaultConfigID: 1,
+		},
+		engine: &testEngine{entityIDs: map[string]int64{"1001": 1, "1002": 1, "1003": 1, "2001": 2}},
+	}
+}
+
+func (factory *testFactory) CreateConfig(ctx context.Context) (senzing.SzConfig, error) {
+	return &factory.config, nil
+}
+
+func (factory *testFactory) CreateConfigManager(ctx context.Context) (senzing.SzConfigManager, error) {
+	return &factory.configManager, nil
+}
+
+func (factory *testFactory) CreateEngine(ctx context.Context) (senzing.SzEngine, error) {
+	return factory.engine, nil
+}
+
+func (factory *testFactory) Reinitialize(ctx context.Context, configID int64) error {
+	factory.reinitializedConfigID = configID
+	return nil
+}
+
+func (config *testConfig) AddDataSource(ctx context.Context, configHandle uintptr, dataSourceCode string) (string, error) {
+	config.dataSources[dataSourceCode] = true
+	return "", nil
+}
+
+func (config *testConfig) CloseConfig(ctx context.Context, configHandle uintptr) error {
+	return nil
+}
+
+func (config *testConfig) ExportConfig(ctx context.Context, configHandle uintptr) (string, error) {
+	dataSources := []string{}
+	for dataSource := range config.dataSources {
+		dataSources = append(dataSources, dataSource)
+	}
+	definition, err := json.Marshal(dataSources)
+	return string(definition), err
+}
+
+func (config *testConfig) GetDataSources(ctx context.Context, configHandle uintptr) (string, error) {
+	type dataSource struct {
+		Code string `json:"DSRC_CODE"`
+		ID   int    `json:"DSRC_ID"`
+	}
+	result := struct {
+		DataSources []dataSource `json:"DATA_SOURCES"`
+	}{}
+	for code := range config.dataSources {
+		result.DataSources = append(result.DataSources, dataSource{Code: code, ID: len(result.DataSources) + 1})
+	}
+	dataSources, err := json.Marshal(result)
+	return string(dataSources), err
+}
+
+func (config *testConfig) ImportConfig(ctx context.Context, configDefinition string) (uintptr, error) {
+	dataSources := []string{}
+	err := json.Unmarshal([]byte(configDefinition), &dataSources)
+	config.dataSources = map[string]bool{}
+	for _, dataSource := range dataSources {
+		config.dataSources[dataSource] = true
+	}
+	return 1, err
+}
+
+func (configManager *testConfigManager) AddConfig(ctx context.Context, configDefinition string, configComments string) (int64, error) {
+	configID := int64(len(configManager.configs) + 1)
+	configManager.configs[configID] = configDefinition
+	return configID, nil
+}
+
+func (configManager *testConfigManager) GetConfig(ctx context.Context, configID int64) (string, error) {
+	return configManager.configs[configID], nil
+}
+
+func (configManager *testConfigManager) GetDefaultConfigID(ctx context.Context) (int64, error) {
+	return configManager.defaultConfigID, nil
+}
+
+func (configManager *testConfigManager) ReplaceDefaultConfigID(ctx context.Context, currentDefaultConfigID int64, newDefaultConfigID int64) error {
+	configManager.defaultConfigID = newDefaultConfigID
+	return nil
+}
+
+func (engine *testEngine) AddRecord(ctx context.Context, dataSourceCode string, recordID string, recordDefinition string, flags int64) (string, error) {
+	if recordID == "REJECT" {
+		return "", errTestAddRecord
+	}
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	engine.recordIDs = append(engine.recordIDs, recordID)
+	if engine.onAdd != nil {
+		engine.onAdd(recordID)
+	}
+	return "", nil
+}
+
+func (engine *testEngine) GetEntityByRecordID(ctx context.Context, dataSourceCode string, recordID string, flags int64) (string, error) {
+	entityID, isAdded := engine.entityIDs[recordID]
+	if !isAdded {
+		return "", errTestGetEntity
+	}
+	return fmt.Sprintf(`{"RESOLVED_ENTITY": {"ENTITY_ID": %d}}`, entityID), nil
+}
+
+func (engine *testEngine) getRecordIDs() []string {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	result := append([]string{}, engine.recordIDs...)
+	sort.Strings(result)
+	return result
+}
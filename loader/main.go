@@ -0,0 +1,229 @@
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Analysis describes the records of a file, as found by Analyze.  Counts are most frequent first.
+type Analysis struct {
+	Attributes  []Count           `json:"attributes"`
+	DataSources []Count           `json:"dataSources"`
+	Invalid     int64             `json:"invalid"`
+	Records     int64             `json:"records"`
+	Samples     []json.RawMessage `json:"samples"`
+}
+
+// Checkpoint is the contents of a checkpoint file: every line of File up to Line has been loaded or failed.
+type Checkpoint struct {
+	File string    `json:"file"`
+	Line int64     `json:"line"`
+	Time time.Time `json:"time"`
+}
+
+// Column maps a column of a CSV or TSV file, by its name in the header row, to a Senzing attribute.
+// A column with no Attribute is left out.
+type Column struct {
+	Attribute string `json:"attribute"`
+	Name      string `json:"name"`
+}
+
+// Count is how many records have an attribute, or are of a data source.
+type Count struct {
+	Count int64  `json:"count"`
+	Name  string `json:"name"`
+}
+
+// Failure is one line of a failures file: a record that could not be added, and why.
+type Failure struct {
+	DataSource string `json:"dataSource,omitempty"`
+	Error      string `json:"error"`
+	Line       int64  `json:"line"`
+	Record     string `json:"record"`
+	RecordID   string `json:"recordId,omitempty"`
+}
+
+/*
+Mapping says how ConvertCSV turns the rows of a CSV or TSV file into records.
+Columns not in Columns are left out.  Records get DataSource as their DATA_SOURCE,
+unless a column maps to DATA_SOURCE, and the number of their line as their RECORD_ID,
+unless a column maps to RECORD_ID.
+*/
+type Mapping struct {
+	Columns    []Column `json:"columns"`
+	DataSource string   `json:"dataSource,omitempty"`
+}
+
+// Result summarizes a load.  In JSON, Elapsed is in nanoseconds.
+type Result struct {
+	DataSources []string      `json:"dataSources"`
+	Elapsed     time.Duration `json:"elapsed"`
+	Failed      int64         `json:"failed"`
+	Loaded      int64         `json:"loaded"`
+	Skipped     int64         `json:"skipped"`
+	Total       int64         `json:"total"`
+}
+
+// Summary describes how the records of a load resolved, as found by Summarize.
+// ResolvedEntities is the number of entities holding more than one of the records.
+type Summary struct {
+	Entities         int64 `json:"entities"`
+	Records          int64 `json:"records"`
+	ResolvedEntities int64 `json:"resolvedEntities"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Default time between progress reports.
+const DefaultProgressInterval = 10 * time.Second
+
+// Default number of records added in parallel.
+const DefaultThreads = 4
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrCheckpointMismatch is returned by Load when resuming from the checkpoint of another file.
+var ErrCheckpointMismatch = errors.New("loader: checkpoint is for another file")
+
+// ErrDuplicateAttribute is returned for a Mapping that maps more than one column to the same attribute.
+var ErrDuplicateAttribute = errors.New("loader: attribute mapped from more than one column")
+
+// ErrEmptyCSV is returned by ConvertCSV for input without a header row.
+var ErrEmptyCSV = errors.New("loader: no header row in CSV")
+
+// ErrInterrupted is returned by Load when its context is cancelled before every record is loaded.
+var ErrInterrupted = errors.New("loader: load interrupted")
+
+// ErrInvalidRecord is recorded as the failure of a line that is not a record with a DATA_SOURCE and RECORD_ID.
+var ErrInvalidRecord = errors.New("loader: invalid record")
+
+// ErrMissingDataSource is returned for a Mapping with no DataSource and no column mapped to DATA_SOURCE.
+var ErrMissingDataSource = errors.New("loader: mapping has no data source")
+
+// ErrMissingFile is returned by Load when InputFile is not set.
+var ErrMissingFile = errors.New("loader: no file to load")
+
+// Attributes are the Senzing attributes SuggestMapping maps columns to, and more.
+var Attributes = []string{
+	"ACCOUNT_DOMAIN",
+	"ACCOUNT_NUMBER",
+	"ADDR_CITY",
+	"ADDR_COUNTRY",
+	"ADDR_FULL",
+	"ADDR_LINE1",
+	"ADDR_LINE2",
+	"ADDR_LINE3",
+	"ADDR_POSTAL_CODE",
+	"ADDR_STATE",
+	"ADDR_TYPE",
+	"CITIZENSHIP",
+	"DATA_SOURCE",
+	"DATE_OF_BIRTH",
+	"DATE_OF_DEATH",
+	"DRIVERS_LICENSE_NUMBER",
+	"DRIVERS_LICENSE_STATE",
+	"DUNS_NUMBER",
+	"EMAIL_ADDRESS",
+	"GENDER",
+	"LEI_NUMBER",
+	"NAME_FIRST",
+	"NAME_FULL",
+	"NAME_LAST",
+	"NAME_MIDDLE",
+	"NAME_ORG",
+	"NAME_PREFIX",
+	"NAME_SUFFIX",
+	"NAME_TYPE",
+	"NATIONAL_ID_COUNTRY",
+	"NATIONAL_ID_NUMBER",
+	"NATIONALITY",
+	"PASSPORT_COUNTRY",
+	"PASSPORT_NUMBER",
+	"PHONE_NUMBER",
+	"PHONE_TYPE",
+	"PLACE_OF_BIRTH",
+	"RECORD_ID",
+	"RECORD_TYPE",
+	"REGISTRATION_COUNTRY",
+	"REGISTRATION_DATE",
+	"SSN_LAST4",
+	"SSN_NUMBER",
+	"TAX_ID_COUNTRY",
+	"TAX_ID_NUMBER",
+	"WEBSITE_ADDRESS",
+}
+
+// Header names, normalized by normalizeColumnName, that SuggestMapping maps to an attribute other than themselves.
+var attributeSynonyms = map[string]string{
+	"ADDRESS":                "ADDR_LINE1",
+	"ADDRESS1":               "ADDR_LINE1",
+	"ADDRESS2":               "ADDR_LINE2",
+	"ADDRESS_1":              "ADDR_LINE1",
+	"ADDRESS_2":              "ADDR_LINE2",
+	"ADDRESS_LINE_1":         "ADDR_LINE1",
+	"ADDRESS_LINE_2":         "ADDR_LINE2",
+	"ADDRESS_LINE1":          "ADDR_LINE1",
+	"ADDRESS_LINE2":          "ADDR_LINE2",
+	"BIRTH_DATE":             "DATE_OF_BIRTH",
+	"BIRTHDATE":              "DATE_OF_BIRTH",
+	"BUSINESS_NAME":          "NAME_ORG",
+	"CELL":                   "PHONE_NUMBER",
+	"CITY":                   "ADDR_CITY",
+	"COMPANY":                "NAME_ORG",
+	"COMPANY_NAME":           "NAME_ORG",
+	"COUNTRY":                "ADDR_COUNTRY",
+	"DOB":                    "DATE_OF_BIRTH",
+	"DRIVERS_LICENSE":        "DRIVERS_LICENSE_NUMBER",
+	"EIN":                    "TAX_ID_NUMBER",
+	"EMAIL":                  "EMAIL_ADDRESS",
+	"E_MAIL":                 "EMAIL_ADDRESS",
+	"FAMILY_NAME":            "NAME_LAST",
+	"FIRST":                  "NAME_FIRST",
+	"FIRST_NAME":             "NAME_FIRST",
+	"FIRSTNAME":              "NAME_FIRST",
+	"FNAME":                  "NAME_FIRST",
+	"FULL_ADDRESS":           "ADDR_FULL",
+	"FULL_NAME":              "NAME_FULL",
+	"FULLNAME":               "NAME_FULL",
+	"GIVEN_NAME":             "NAME_FIRST",
+	"ID":                     "RECORD_ID",
+	"LAST":                   "NAME_LAST",
+	"LAST_NAME":              "NAME_LAST",
+	"LASTNAME":               "NAME_LAST",
+	"LNAME":                  "NAME_LAST",
+	"MIDDLE":                 "NAME_MIDDLE",
+	"MIDDLE_NAME":            "NAME_MIDDLE",
+	"MOBILE":                 "PHONE_NUMBER",
+	"NAME":                   "NAME_FULL",
+	"ORG_NAME":               "NAME_ORG",
+	"ORGANIZATION":           "NAME_ORG",
+	"ORGANIZATION_NAME":      "NAME_ORG",
+	"PASSPORT":               "PASSPORT_NUMBER",
+	"PHONE":                  "PHONE_NUMBER",
+	"POSTAL_CODE":            "ADDR_POSTAL_CODE",
+	"POSTCODE":               "ADDR_POSTAL_CODE",
+	"PROVINCE":               "ADDR_STATE",
+	"SEX":                    "GENDER",
+	"SOCIAL_SECURITY_NUMBER": "SSN_NUMBER",
+	"SSN":                    "SSN_NUMBER",
+	"STATE":                  "ADDR_STATE",
+	"STREET":                 "ADDR_LINE1",
+	"STREET_ADDRESS":         "ADDR_LINE1",
+	"SURNAME":                "NAME_LAST",
+	"TAX_ID":                 "TAX_ID_NUMBER",
+	"TELEPHONE":              "PHONE_NUMBER",
+	"URL":                    "WEBSITE_ADDRESS",
+	"WEBSITE":                "WEBSITE_ADDRESS",
+	"ZIP":                    "ADDR_POSTAL_CODE",
+	"ZIP_CODE":               "ADDR_POSTAL_CODE",
+	"ZIPCODE":                "ADDR_POSTAL_CODE",
+}
@@ -0,0 +1,5 @@
+/*
+Package metrics collects Prometheus metrics for the playground's HTTP routes,
+reverse proxies, xterm sessions, and gRPC calls, and serves them for scraping.
+*/
+package metrics
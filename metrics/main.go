@@ -0,0 +1,17 @@
+package metrics
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Namespace prefixed to the name of every metric.  Example: "playground_http_requests_total".
+const Namespace = "playground"
+
+// Values of the "listener" label of gRPC metrics.
+const (
+	ListenerInProcess = "in-process"
+	ListenerNetwork   = "network"
+)
+
+// Value of the "route" label for requests that match no route.
+const RouteNone = "none"
@@ -0,0 +1,277 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicMetrics holds the playground's metrics in a registry of its own.
+The zero value is ready to use.  Every method of a nil *BasicMetrics is a no-op
+that returns its input unchanged, so instrumentation can be disabled by passing nil.
+*/
+type BasicMetrics struct {
+	grpcHandledTotal    *prometheus.CounterVec
+	grpcHandlingSeconds *prometheus.HistogramVec
+	httpDuration        *prometheus.HistogramVec
+	httpRequestsTotal   *prometheus.CounterVec
+	once                sync.Once
+	proxyErrorsTotal    *prometheus.CounterVec
+	proxyRequestsTotal  *prometheus.CounterVec
+	registry            *prometheus.Registry
+	xtermSessions       prometheus.Gauge
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// instrumentedTransport counts the requests a reverse proxy sends upstream.
+type instrumentedTransport struct {
+	metrics   *BasicMetrics
+	transport http.RoundTripper
+	upstream  string
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The GrpcServerOptions method returns options that count and time each call a gRPC server handles.
+
+Input
+  - listener: Value of the "listener" label.  Example: ListenerNetwork.
+
+Output
+  - Options for grpc.NewServer.
+*/
+func (metrics *BasicMetrics) GrpcServerOptions(listener string) []grpc.ServerOption {
+	if metrics == nil {
+		return nil
+	}
+	metrics.initialize()
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			started := time.Now()
+			response, err := handler(ctx, request)
+			metrics.observeGrpcCall(listener, info.FullMethod, started, err)
+			return response, err
+		}),
+		grpc.ChainStreamInterceptor(func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			started := time.Now()
+			err := handler(server, stream)
+			metrics.observeGrpcCall(listener, info.FullMethod, started, err)
+			return err
+		}),
+	}
+}
+
+/*
+The Handler method serves the metrics in the Prometheus exposition format.
+
+Output
+  - A handler for the "/metrics" route.  For a nil *BasicMetrics, http.NotFoundHandler.
+*/
+func (metrics *BasicMetrics) Handler() http.Handler {
+	if metrics == nil {
+		return http.NotFoundHandler()
+	}
+	metrics.initialize()
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{Registry: metrics.registry})
+}
+
+/*
+The InstrumentHandler method counts and times each request a handler serves, labelled by route.
+Label with a route pattern, not the request path, so the number of series stays bounded.
+
+Input
+  - handler: The handler to instrument.
+  - route: Returns the route a request matches, or "" for none.  Example: the pattern from http.ServeMux.Handler.
+
+Output
+  - The instrumented handler.
+*/
+func (metrics *BasicMetrics) InstrumentHandler(handler http.Handler, route func(r *http.Request) string) http.Handler {
+	if metrics == nil {
+		return handler
+	}
+	metrics.initialize()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		routeName := route(r)
+		if len(routeName) == 0 {
+			routeName = RouteNone
+		}
+		handler.ServeHTTP(recorder, r)
+		metrics.httpRequestsTotal.WithLabelValues(routeName, r.Method, strconv.Itoa(recorder.statusCode)).Inc()
+		metrics.httpDuration.WithLabelValues(routeName, r.Method).Observe(time.Since(started).Seconds())
+	})
+}
+
+/*
+The InstrumentTransport method counts the requests a reverse proxy sends upstream,
+and the ones that fail without a response.
+
+Input
+  - upstream: Value of the "upstream" label.  Example: "jupyter-lab".
+  - transport: The transport to instrument.  If nil, http.DefaultTransport.
+
+Output
+  - The instrumented transport.
+*/
+func (metrics *BasicMetrics) InstrumentTransport(upstream string, transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if metrics == nil {
+		return transport
+	}
+	metrics.initialize()
+	return &instrumentedTransport{
+		metrics:   metrics,
+		transport: transport,
+		upstream:  upstream,
+	}
+}
+
+// The XtermSessionStarted method counts an xterm session as open.
+func (metrics *BasicMetrics) XtermSessionStarted() {
+	if metrics == nil {
+		return
+	}
+	metrics.initialize()
+	metrics.xtermSessions.Inc()
+}
+
+// The XtermSessionEnded method counts an xterm session as closed.
+func (metrics *BasicMetrics) XtermSessionEnded() {
+	if metrics == nil {
+		return
+	}
+	metrics.initialize()
+	metrics.xtermSessions.Dec()
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Create and register the collectors, once.
+func (metrics *BasicMetrics) initialize() {
+	metrics.once.Do(func() {
+		metrics.grpcHandledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "grpc_server_handled_total",
+			Help:      "gRPC calls handled, by listener, method, and status code.",
+		}, []string{"listener", "method", "code"})
+		metrics.grpcHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "grpc_server_handling_seconds",
+			Help:      "Time to handle gRPC calls, by listener and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"listener", "method"})
+		metrics.httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time to serve HTTP requests, by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"})
+		metrics.httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by route, method, and status code.",
+		}, []string{"route", "method", "code"})
+		metrics.proxyErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "proxy_upstream_errors_total",
+			Help:      "Reverse-proxy requests that got no response from upstream, by upstream.",
+		}, []string{"upstream"})
+		metrics.proxyRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "proxy_upstream_requests_total",
+			Help:      "Reverse-proxy requests sent upstream, by upstream and status code.",
+		}, []string{"upstream", "code"})
+		metrics.xtermSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "xterm_sessions",
+			Help:      "Open xterm sessions.",
+		})
+		metrics.registry = prometheus.NewRegistry()
+		metrics.registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			metrics.grpcHandledTotal,
+			metrics.grpcHandlingSeconds,
+			metrics.httpDuration,
+			metrics.httpRequestsTotal,
+			metrics.proxyErrorsTotal,
+			metrics.proxyRequestsTotal,
+			metrics.xtermSessions,
+		)
+	})
+}
+
+func (metrics *BasicMetrics) observeGrpcCall(listener string, method string, started time.Time, err error) {
+	metrics.grpcHandledTotal.WithLabelValues(listener, method, status.Code(err).String()).Inc()
+	metrics.grpcHandlingSeconds.WithLabelValues(listener, method).Observe(time.Since(started).Seconds())
+}
+
+// --- statusRecorder ---------------------------------------------------------
+
+func (recorder *statusRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush, so streamed responses, like those of reverse proxies, are not held back.
+func (recorder *statusRecorder) Flush() {
+	if flusher, isFlusher := recorder.ResponseWriter.(http.Flusher); isFlusher {
+		flusher.Flush()
+	}
+}
+
+// Hijack, so websockets, like xterm's, can be upgraded.
+func (recorder *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, isHijacker := recorder.ResponseWriter.(http.Hijacker)
+	if !isHijacker {
+		return nil, nil, errors.New("metrics: the response writer does not support hijacking")
+	}
+	recorder.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+func (recorder *statusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
+// --- instrumentedTransport --------------------------------------------------
+
+func (transport *instrumentedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := transport.transport.RoundTrip(request)
+	if err != nil {
+		transport.metrics.proxyErrorsTotal.WithLabelValues(transport.upstream).Inc()
+		return response, err
+	}
+	transport.metrics.proxyRequestsTotal.WithLabelValues(transport.upstream, strconv.Itoa(response.StatusCode)).Inc()
+	return response, err
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestBasicMetrics_GrpcServerOptions(test *testing.T) {
+	ctx := context.TODO()
+	metrics := &BasicMetrics{}
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(metrics.GrpcServerOptions(ListenerInProcess)...)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+	connection, err := grpc.NewClient("passthrough:///in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(test, err)
+	defer connection.Close()
+	client := grpc_health_v1.NewHealthClient(connection)
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(test, err)
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "missing"})
+	require.Error(test, err)
+
+	body := scrape(test, metrics)
+	assert.Contains(test, body, `playground_grpc_server_handled_total{code="OK",listener="in-process",method="/grpc.health.v1.Health/Check"} 1`)
+	assert.Contains(test, body, `playground_grpc_server_handled_total{code="NotFound",listener="in-process",method="/grpc.health.v1.Health/Check"} 1`)
+	assert.Contains(test, body, `playground_grpc_server_handling_seconds_count{listener="in-process",method="/grpc.health.v1.Health/Check"} 2`)
+}
+
+func TestBasicMetrics_InstrumentHandler(test *testing.T) {
+	metrics := &BasicMetrics{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	handler := metrics.InstrumentHandler(mux, func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	})
+	for _, path := range []string{"/items/1", "/items/2", "/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(test, metrics)
+	assert.Contains(test, body, `playground_http_requests_total{code="202",method="GET",route="/items/{id}"} 2`)
+	assert.Contains(test, body, `playground_http_requests_total{code="404",method="GET",route="none"} 1`)
+	assert.Contains(test, body, `playground_http_request_duration_seconds_count{method="GET",route="/items/{id}"} 2`)
+}
+
+func TestBasicMetrics_InstrumentHandler_hijack(test *testing.T) {
+	metrics := &BasicMetrics{}
+	handler := metrics.InstrumentHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connection, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(test, err)
+		_, _ = io.WriteString(connection, "HTTP/1.1 204 No Content\r\n\r\n")
+		connection.Close()
+	}), func(r *http.Request) string { return "/hijack" })
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	response, err := http.Get(server.URL)
+	require.NoError(test, err)
+	response.Body.Close()
+	assert.Contains(test, scrape(test, metrics), `playground_http_requests_total{code="101",method="GET",route="/hijack"} 1`)
+}
+
+func TestBasicMetrics_InstrumentTransport(test *testing.T) {
+	metrics := &BasicMetrics{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	client := &http.Client{Transport: metrics.InstrumentTransport("test", nil)}
+	response, err := client.Get(upstream.URL)
+	require.NoError(test, err)
+	response.Body.Close()
+	upstream.Close()
+	_, err = client.Get(upstream.URL) //nolint:bodyclose
+	require.Error(test, err)
+
+	body := scrape(test, metrics)
+	assert.Contains(test, body, `playground_proxy_upstream_requests_total{code="418",upstream="test"} 1`)
+	assert.Contains(test, body, `playground_proxy_upstream_errors_total{upstream="test"} 1`)
+}
+
+func TestBasicMetrics_XtermSessions(test *testing.T) {
+	metrics := &BasicMetrics{}
+	metrics.XtermSessionStarted()
+	metrics.XtermSessionStarted()
+	metrics.XtermSessionEnded()
+	assert.Contains(test, scrape(test, metrics), "playground_xterm_sessions 1\n")
+}
+
+func TestBasicMetrics_nil(test *testing.T) {
+	var metrics *BasicMetrics
+	handler := http.NotFoundHandler()
+	assert.Nil(test, metrics.GrpcServerOptions(ListenerNetwork))
+	assert.NotNil(test, metrics.InstrumentHandler(handler, nil))
+	assert.Equal(test, http.DefaultTransport, metrics.InstrumentTransport("test", nil))
+	metrics.XtermSessionStarted()
+	metrics.XtermSessionEnded()
+	response := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(test, http.StatusNotFound, response.Code)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func scrape(test *testing.T, metrics *BasicMetrics) string {
+	response := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(test, http.StatusOK, response.Code)
+	body := response.Body.String()
+	require.True(test, strings.Contains(body, "go_goroutines"))
+	return body
+}
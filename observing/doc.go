@@ -0,0 +1,6 @@
+/*
+Package observing provides go-observing observers for the playground:
+one that forwards messages to a gRPC observer service, one that appends them to a file,
+and one that keeps the most recent messages in memory.
+*/
+package observing
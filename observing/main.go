@@ -0,0 +1,23 @@
+package observing
+
+import "errors"
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Default port of a gRPC observer service, used when an observer URL has none.
+const DefaultGrpcObserverPort = "8260"
+
+// Default number of messages a BufferObserver keeps.
+const DefaultBufferSize = 1000
+
+// Number of messages a BufferObserver subscription holds for a slow reader before dropping them.
+const SubscriptionBufferSize = 100
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrUnsupportedScheme is returned by NewObserver for an observer URL whose scheme is not "grpc".
+var ErrUnsupportedScheme = errors.New("observing: unsupported observer URL scheme")
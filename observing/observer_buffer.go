@@ -0,0 +1,127 @@
+package observing
+
+import (
+	"context"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BufferObserver keeps the most recent Size messages in memory, oldest first.
+A Size of zero keeps DefaultBufferSize messages.
+Subscribers are also sent each message as it arrives.
+*/
+type BufferObserver struct {
+	ID          string
+	messages    []string
+	mutex       sync.Mutex
+	next        int
+	Size        int
+	subscribers map[chan string]struct{}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The GetObserverID method returns the unique identifier of the observer.
+Use by the subject to manage the list of Observers.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (observer *BufferObserver) GetObserverID(ctx context.Context) string {
+	_ = ctx
+	return observer.ID
+}
+
+/*
+The UpdateObserver method keeps the message, discarding the oldest if the buffer is full,
+and sends it to each subscriber.  A subscriber that is SubscriptionBufferSize messages behind misses it.
+
+Input
+  - ctx: A context to control lifecycle.
+  - message: The string to propagate to all registered Observers.
+*/
+func (observer *BufferObserver) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	if len(observer.messages) < observer.getSize() {
+		observer.messages = append(observer.messages, message)
+	} else {
+		observer.messages[observer.next] = message
+		observer.next = (observer.next + 1) % len(observer.messages)
+	}
+	for subscriber := range observer.subscribers {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Messages method returns the messages kept, oldest first.
+
+Output
+  - A copy of the messages.
+*/
+func (observer *BufferObserver) Messages() []string {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	return observer.getMessages()
+}
+
+/*
+The Subscribe method returns the messages kept and a channel of the messages that follow.
+
+Output
+  - A copy of the messages kept, oldest first.
+  - A channel receiving each later message.
+  - A function that ends the subscription and closes the channel.  Call it when done.
+*/
+func (observer *BufferObserver) Subscribe() ([]string, <-chan string, func()) {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	if observer.subscribers == nil {
+		observer.subscribers = map[chan string]struct{}{}
+	}
+	subscriber := make(chan string, SubscriptionBufferSize)
+	observer.subscribers[subscriber] = struct{}{}
+	unsubscribe := func() {
+		observer.mutex.Lock()
+		defer observer.mutex.Unlock()
+		if _, isSubscribed := observer.subscribers[subscriber]; isSubscribed {
+			delete(observer.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+	return observer.getMessages(), subscriber, unsubscribe
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// Callers hold the mutex.
+func (observer *BufferObserver) getMessages() []string {
+	result := make([]string, 0, len(observer.messages))
+	result = append(result, observer.messages[observer.next:]...)
+	return append(result, observer.messages[:observer.next]...)
+}
+
+func (observer *BufferObserver) getSize() int {
+	if observer.Size <= 0 {
+		return DefaultBufferSize
+	}
+	return observer.Size
+}
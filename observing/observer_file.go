@@ -0,0 +1,97 @@
+package observing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// FileObserver appends each message to a file, one per line.
+type FileObserver struct {
+	file  *os.File
+	ID    string
+	mutex sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewFileObserver function opens a file for appending, creating it if needed,
+and returns an observer that writes to it.
+
+Input
+  - id: The unique identifier of the observer.
+  - filename: Path of the file.
+
+Output
+  - The observer.  Close it when done.
+*/
+func NewFileObserver(id string, filename string) (*FileObserver, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileObserver{
+		file: file,
+		ID:   id,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The GetObserverID method returns the unique identifier of the observer.
+Use by the subject to manage the list of Observers.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (observer *FileObserver) GetObserverID(ctx context.Context) string {
+	_ = ctx
+	return observer.ID
+}
+
+/*
+The UpdateObserver method appends the message to the file.
+
+Input
+  - ctx: A context to control lifecycle.
+  - message: The string to propagate to all registered Observers.
+*/
+func (observer *FileObserver) UpdateObserver(ctx context.Context, message string) {
+	_ = ctx
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	if observer.file == nil {
+		return
+	}
+	_, err := fmt.Fprintln(observer.file, message)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Observer: %s;  Message: %s; Error: %v\n", observer.ID, message, err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+// The Close method closes the file.  Later messages are not written.
+func (observer *FileObserver) Close() error {
+	observer.mutex.Lock()
+	defer observer.mutex.Unlock()
+	if observer.file == nil {
+		return nil
+	}
+	err := observer.file.Close()
+	observer.file = nil
+	return err
+}
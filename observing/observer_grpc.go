@@ -0,0 +1,60 @@
+package observing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/go-observing/observerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewGrpcObserver function returns an observer that sends messages to a gRPC observer service.
+
+Input
+  - ctx: A context to control lifecycle.
+  - parsedURL: Address of the service.  Example: "grpc://localhost:8260".  Without a port, DefaultGrpcObserverPort.
+  - id: The unique identifier of the observer.
+*/
+func NewGrpcObserver(ctx context.Context, parsedURL url.URL, id string) (*observer.GrpcObserver, error) {
+	_ = ctx
+	port := DefaultGrpcObserverPort
+	if len(parsedURL.Port()) > 0 {
+		port = parsedURL.Port()
+	}
+	target := fmt.Sprintf("%s:%s", parsedURL.Hostname(), port)
+	grpcConnection, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &observer.GrpcObserver{
+		GrpcClient: observerpb.NewObserverClient(grpcConnection),
+		ID:         id,
+	}, nil
+}
+
+/*
+The NewObserver function returns an observer that sends messages to an observer URL.
+
+Input
+  - ctx: A context to control lifecycle.
+  - observerURL: Address of the observer.  Only the "grpc" scheme is supported.  Example: "grpc://localhost:8260".
+  - id: The unique identifier of the observer.
+*/
+func NewObserver(ctx context.Context, observerURL string, id string) (observer.Observer, error) {
+	parsedURL, err := url.Parse(observerURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme != "grpc" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedScheme, observerURL)
+	}
+	return NewGrpcObserver(ctx, *parsedURL, id)
+}
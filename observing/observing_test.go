@@ -0,0 +1,97 @@
+package observing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBufferObserver_UpdateObserver(test *testing.T) {
+	ctx := context.TODO()
+	observer := &BufferObserver{ID: "test", Size: 3}
+	assert.Equal(test, "test", observer.GetObserverID(ctx))
+	assert.Empty(test, observer.Messages())
+	for index := 1; index <= 5; index++ {
+		observer.UpdateObserver(ctx, fmt.Sprintf("message %d", index))
+	}
+	assert.Equal(test, []string{"message 3", "message 4", "message 5"}, observer.Messages())
+}
+
+func TestBufferObserver_UpdateObserver_defaultSize(test *testing.T) {
+	ctx := context.TODO()
+	observer := &BufferObserver{}
+	for index := 0; index <= DefaultBufferSize; index++ {
+		observer.UpdateObserver(ctx, fmt.Sprintf("message %d", index))
+	}
+	messages := observer.Messages()
+	assert.Len(test, messages, DefaultBufferSize)
+	assert.Equal(test, "message 1", messages[0])
+}
+
+func TestBufferObserver_Subscribe(test *testing.T) {
+	ctx := context.TODO()
+	observer := &BufferObserver{ID: "test", Size: 2}
+	observer.UpdateObserver(ctx, "message 1")
+	recent, updates, unsubscribe := observer.Subscribe()
+	assert.Equal(test, []string{"message 1"}, recent)
+	observer.UpdateObserver(ctx, "message 2")
+	assert.Equal(test, "message 2", <-updates)
+	for index := 0; index <= SubscriptionBufferSize; index++ {
+		observer.UpdateObserver(ctx, fmt.Sprintf("message %d", index+3))
+	}
+	assert.Len(test, updates, SubscriptionBufferSize)
+	unsubscribe()
+	unsubscribe()
+	observer.UpdateObserver(ctx, "message last")
+	received := 0
+	for range updates {
+		received++
+	}
+	assert.Equal(test, SubscriptionBufferSize, received)
+}
+
+func TestFileObserver_UpdateObserver(test *testing.T) {
+	ctx := context.TODO()
+	filename := filepath.Join(test.TempDir(), "observations.jsonl")
+	require.NoError(test, os.WriteFile(filename, []byte("{\"earlier\": true}\n"), 0o600))
+	observer, err := NewFileObserver("test", filename)
+	require.NoError(test, err)
+	assert.Equal(test, "test", observer.GetObserverID(ctx))
+	observer.UpdateObserver(ctx, `{"messageId": "1"}`)
+	observer.UpdateObserver(ctx, `{"messageId": "2"}`)
+	require.NoError(test, observer.Close())
+	observer.UpdateObserver(ctx, `{"messageId": "3"}`)
+	require.NoError(test, observer.Close())
+	contents, err := os.ReadFile(filename)
+	require.NoError(test, err)
+	assert.Equal(test, "{\"earlier\": true}\n{\"messageId\": \"1\"}\n{\"messageId\": \"2\"}\n", string(contents))
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestNewFileObserver_badFilename(test *testing.T) {
+	_, err := NewFileObserver("test", test.TempDir())
+	require.Error(test, err)
+}
+
+func TestNewObserver(test *testing.T) {
+	ctx := context.TODO()
+	observer, err := NewObserver(ctx, "grpc://localhost", "test")
+	require.NoError(test, err)
+	assert.Equal(test, "test", observer.GetObserverID(ctx))
+	_, err = NewObserver(ctx, "http://localhost:8260", "test")
+	require.ErrorIs(test, err, ErrUnsupportedScheme)
+	_, err = NewObserver(ctx, "grpc://%zz", "test")
+	require.Error(test, err)
+}
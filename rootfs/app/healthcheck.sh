@@ -1,12 +1,10 @@
 #!/usr/bin/env bash
 
-# Return codes.
-
-OK=0
-NOT_OK=1
-
-# Tests.
-
-echo "Doing healthtest."
-
-exit ${OK}
+# Healthy once the playground reports it is ready.
+# "playground healthcheck" reads the same SENZING_TOOLS_* variables as the playground,
+# so it finds the running server's port, base path, and scheme.
+# With SENZING_TOOLS_HTTP_TLS_CERT_FILE, also set SENZING_TOOLS_PLAYGROUND_HOST to a name
+# the certificate is issued for, and SENZING_TOOLS_TLS_CA_FILE unless a system CA issued it.
+# With authentication, only readiness is checked, unless SENZING_TOOLS_HTTP_BEARER_TOKEN is set.
+
+exec /app/playground healthcheck
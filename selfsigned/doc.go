@@ -0,0 +1,5 @@
+/*
+Package selfsigned generates self-signed TLS certificates, and a local certificate authority
+to sign them, for local use.
+*/
+package selfsigned
@@ -0,0 +1,157 @@
+package selfsigned
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// ValidFor is how long a generated certificate is valid.
+const ValidFor = 365 * 24 * time.Hour
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Generate function creates a self-signed certificate and its private key.
+
+Input
+  - hostnames: DNS names and IP addresses the certificate is valid for.
+    The first one is also used as the certificate's common name.
+
+Output
+  - The PEM-encoded certificate.
+  - The PEM-encoded private key.
+*/
+func Generate(hostnames []string) ([]byte, []byte, error) {
+	template, err := newTemplate(hostnames)
+	if err != nil {
+		return nil, nil, err
+	}
+	return create(template, nil, nil)
+}
+
+/*
+The GenerateCertificateAuthority function creates a self-signed certificate
+authority (CA) suitable for signing certificates with GenerateSignedBy.
+
+Input
+  - commonName: The common name of the CA.
+
+Output
+  - The PEM-encoded CA certificate.
+  - The PEM-encoded CA private key.
+*/
+func GenerateCertificateAuthority(commonName string) ([]byte, []byte, error) {
+	template, err := newTemplate([]string{})
+	if err != nil {
+		return nil, nil, err
+	}
+	template.Subject.CommonName = commonName
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	template.ExtKeyUsage = nil
+	template.IsCA = true
+	return create(template, nil, nil)
+}
+
+/*
+The GenerateSignedBy function creates a certificate, signed by a CA, and its private key.
+The certificate may be used by both servers and clients.
+
+Input
+  - certificateAuthorityPEM: The PEM-encoded CA certificate.
+  - certificateAuthorityKeyPEM: The PEM-encoded CA private key.
+  - commonName: The common name of the certificate.
+  - hostnames: DNS names and IP addresses the certificate is valid for.
+
+Output
+  - The PEM-encoded certificate.
+  - The PEM-encoded private key.
+*/
+func GenerateSignedBy(certificateAuthorityPEM []byte, certificateAuthorityKeyPEM []byte, commonName string, hostnames []string) ([]byte, []byte, error) {
+	certificateAuthority, err := tls.X509KeyPair(certificateAuthorityPEM, certificateAuthorityKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := x509.ParseCertificate(certificateAuthority.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := newTemplate(hostnames)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.Subject.CommonName = commonName
+	return create(template, parent, certificateAuthority.PrivateKey)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Sign template with parentKey, or self-sign it when parent is nil.
+func create(template *x509.Certificate, parent *x509.Certificate, parentKey crypto.PrivateKey) ([]byte, []byte, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent == nil {
+		parent = template
+		parentKey = privateKey
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, parent, &privateKey.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER})
+	return certificatePEM, privateKeyPEM, nil
+}
+
+func newTemplate(hostnames []string) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	result := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"Senzing Playground"}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(ValidFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	if len(hostnames) > 0 {
+		result.Subject.CommonName = hostnames[0]
+	}
+	for _, hostname := range hostnames {
+		if ipAddress := net.ParseIP(hostname); ipAddress != nil {
+			result.IPAddresses = append(result.IPAddresses, ipAddress)
+		} else {
+			result.DNSNames = append(result.DNSNames, hostname)
+		}
+	}
+	return result, nil
+}
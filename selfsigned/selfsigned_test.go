@@ -0,0 +1,57 @@
+package selfsigned
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestGenerate(test *testing.T) {
+	certificatePEM, privateKeyPEM, err := Generate([]string{"localhost", "127.0.0.1", "::1"})
+	require.NoError(test, err)
+	certificate, err := tls.X509KeyPair(certificatePEM, privateKeyPEM)
+	require.NoError(test, err)
+	parsedCertificate, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(test, err)
+	assert.Equal(test, "localhost", parsedCertificate.Subject.CommonName)
+	require.NoError(test, parsedCertificate.VerifyHostname("localhost"))
+	require.NoError(test, parsedCertificate.VerifyHostname("127.0.0.1"))
+	require.NoError(test, parsedCertificate.VerifyHostname("::1"))
+	require.Error(test, parsedCertificate.VerifyHostname("example.com"))
+}
+
+func TestGenerate_noHostnames(test *testing.T) {
+	certificatePEM, privateKeyPEM, err := Generate([]string{})
+	require.NoError(test, err)
+	_, err = tls.X509KeyPair(certificatePEM, privateKeyPEM)
+	require.NoError(test, err)
+}
+
+func TestGenerateSignedBy(test *testing.T) {
+	certificateAuthorityPEM, certificateAuthorityKeyPEM, err := GenerateCertificateAuthority("Test CA")
+	require.NoError(test, err)
+	certificatePEM, privateKeyPEM, err := GenerateSignedBy(certificateAuthorityPEM, certificateAuthorityKeyPEM, "server", []string{"localhost"})
+	require.NoError(test, err)
+	certificate, err := tls.X509KeyPair(certificatePEM, privateKeyPEM)
+	require.NoError(test, err)
+	parsedCertificate, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(test, err)
+	roots := x509.NewCertPool()
+	require.True(test, roots.AppendCertsFromPEM(certificateAuthorityPEM))
+	_, err = parsedCertificate.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots})
+	require.NoError(test, err)
+	_, err = parsedCertificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(test, err)
+}
+
+func TestGenerateSignedBy_badCertificateAuthority(test *testing.T) {
+	_, _, err := GenerateSignedBy([]byte("bad"), []byte("bad"), "server", []string{"localhost"})
+	require.Error(test, err)
+}
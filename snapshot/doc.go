@@ -0,0 +1,6 @@
+/*
+Package snapshot saves named copies of a SQLite Senzing repository, and restores them,
+or the baseline repository, in place.  With a Server, this is done while the server runs:
+its gRPC calls are held back meanwhile, and its Senzing engine is reinitialized afterwards.
+*/
+package snapshot
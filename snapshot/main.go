@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Programs are programs with Senzing engines of their own that use the repository,
+// such as those supervisor.BasicSupervisor runs.
+type Programs interface {
+	Restart(ctx context.Context) error
+}
+
+// Server is a server whose Senzing engine uses the repository, such as grpcserver.BasicGrpcServer.
+type Server interface {
+	Reinitialize(ctx context.Context) error
+	WithoutCalls(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Snapshot describes a saved copy of the repository.  Time is when it was saved.
+type Snapshot struct {
+	Name string    `json:"name"`
+	Size int64     `json:"size"`
+	Time time.Time `json:"time"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// BaselineName is the name by which Restore restores the baseline repository.
+// No snapshot may have it.
+const BaselineName = "baseline"
+
+// How long to wait before retrying a copy the database is too busy for.
+const busyRetryInterval = 50 * time.Millisecond
+
+// Extension of the files snapshots are saved in.
+const fileExtension = ".db"
+
+// Extension of a file while a snapshot is saved to it.
+const partialExtension = ".partial"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrExists is returned by Create for a name already taken by a snapshot.
+var ErrExists = errors.New("snapshot: a snapshot of that name exists")
+
+// ErrInvalidName is returned for a name that is not letters, digits, '.', '-', and '_', starting with a letter or digit.
+var ErrInvalidName = errors.New("snapshot: invalid name; use up to 64 letters, digits, '.', '-', and '_', starting with a letter or digit")
+
+// ErrNoBaseline is returned by Restore for BaselineName when SaveBaseline has not saved the baseline.
+var ErrNoBaseline = errors.New("snapshot: no baseline repository to restore; it is saved when the playground starts")
+
+// ErrNotFound is returned for a name no snapshot has.
+var ErrNotFound = errors.New("snapshot: no such snapshot")
+
+// ErrUnsupportedDatabase is returned by DatabaseFile for settings whose repository is not a single SQLite file.
+var ErrUnsupportedDatabase = errors.New("snapshot: only a repository in a SQLite database file can be snapshotted")
+
+// Names a snapshot may have.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
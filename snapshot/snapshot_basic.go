@@ -0,0 +1,352 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/senzing-garage/go-databasing/dbhelper"
+	"github.com/senzing-garage/go-helpers/settingsparser"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicSnapshots saves snapshots of the SQLite repository in DatabaseFile to Directory,
+a file per snapshot, and restores them.  The baseline, what Restore restores for
+BaselineName, is saved to Directory too, by SaveBaseline.
+Copies are made with SQLite's online backup, so they are consistent.  With Server,
+they are made while its gRPC calls are held back, as the Senzing engine's own SQLite
+does not see the locks taken here, and its engine is reinitialized after a restore.
+With Programs, they are restarted after a restore, so their engines see it too.
+One snapshot is saved or restored at a time.
+*/
+type BasicSnapshots struct {
+	DatabaseFile string
+	Directory    string
+	mutex        sync.Mutex
+	Programs     Programs
+	Server       Server
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Create method saves a snapshot of the repository.
+
+Input
+  - ctx: A context to control lifecycle.
+  - name: Name of the new snapshot.
+
+Output
+  - The snapshot saved.  ErrExists if there is one of that name.
+*/
+func (snapshots *BasicSnapshots) Create(ctx context.Context, name string) (Snapshot, error) {
+	snapshots.mutex.Lock()
+	defer snapshots.mutex.Unlock()
+	file, err := snapshots.getFile(name)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	_, err = os.Stat(file)
+	if err == nil {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrExists, name)
+	}
+	err = os.MkdirAll(snapshots.Directory, 0o750)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	// Copy to a partial file, renamed once complete, so List never sees half a snapshot.
+
+	partialFile := file + partialExtension
+	err = snapshots.withoutCalls(ctx, func(ctx context.Context) error {
+		return backup(ctx, partialFile, snapshots.DatabaseFile)
+	})
+	if err != nil {
+		_ = os.Remove(partialFile)
+		return Snapshot{}, err
+	}
+	err = os.Rename(partialFile, file)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return describe(file)
+}
+
+/*
+The Delete method removes a snapshot.
+
+Input
+  - ctx: A context to control lifecycle.
+  - name: Name of the snapshot.
+*/
+func (snapshots *BasicSnapshots) Delete(ctx context.Context, name string) error {
+	_ = ctx
+	snapshots.mutex.Lock()
+	defer snapshots.mutex.Unlock()
+	file, err := snapshots.getFile(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return err
+}
+
+/*
+The List method describes the snapshots saved, oldest first.
+There are none until Directory exists.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (snapshots *BasicSnapshots) List(ctx context.Context) ([]Snapshot, error) {
+	_ = ctx
+	result := []Snapshot{}
+	entries, err := os.ReadDir(snapshots.Directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name, isSnapshot := strings.CutSuffix(entry.Name(), fileExtension)
+		if !isSnapshot || entry.IsDir() || !namePattern.MatchString(name) || name == BaselineName {
+			continue
+		}
+		snapshot, err := describe(filepath.Join(snapshots.Directory, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, snapshot)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+	return result, nil
+}
+
+/*
+The Restore method replaces the contents of the repository with a snapshot's,
+or with the baseline's for BaselineName.
+
+Input
+  - ctx: A context to control lifecycle.
+  - name: Name of the snapshot, or BaselineName.
+*/
+func (snapshots *BasicSnapshots) Restore(ctx context.Context, name string) error {
+	snapshots.mutex.Lock()
+	defer snapshots.mutex.Unlock()
+	file := snapshots.getBaselineFile()
+	notFoundErr := ErrNoBaseline
+	if name != BaselineName {
+		var err error
+		file, err = snapshots.getFile(name)
+		if err != nil {
+			return err
+		}
+		notFoundErr = fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	_, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return notFoundErr
+	}
+	if err != nil {
+		return err
+	}
+	err = snapshots.withoutCalls(ctx, func(ctx context.Context) error {
+		err := backup(ctx, snapshots.DatabaseFile, file)
+		if err != nil || snapshots.Server == nil {
+			return err
+		}
+		return snapshots.Server.Reinitialize(ctx)
+	})
+	if err != nil || snapshots.Programs == nil {
+		return err
+	}
+	return snapshots.Programs.Restart(ctx)
+}
+
+/*
+The SaveBaseline method saves the baseline, unless it has been saved.
+The repository is not touched: build creates the baseline in the file it is given.
+
+Input
+  - ctx: A context to control lifecycle.
+  - build: Creates a SQLite repository in a file, such as with the Senzing schema and default configuration.
+
+Output
+  - Whether the baseline was saved now, rather than before.
+*/
+func (snapshots *BasicSnapshots) SaveBaseline(ctx context.Context, build func(ctx context.Context, file string) error) (bool, error) {
+	snapshots.mutex.Lock()
+	defer snapshots.mutex.Unlock()
+	file := snapshots.getBaselineFile()
+	_, err := os.Stat(file)
+	if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	err = os.MkdirAll(snapshots.Directory, 0o750)
+	if err != nil {
+		return false, err
+	}
+
+	// Build in a partial file, renamed once complete, so Restore never sees half a baseline.
+
+	partialFile := file + partialExtension
+	_ = os.Remove(partialFile)
+	err = build(ctx, partialFile)
+	if err != nil {
+		_ = os.Remove(partialFile)
+		return false, err
+	}
+	return true, os.Rename(partialFile, file)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The DatabaseFile function returns the path of the SQLite database file
+that Senzing engine settings name as the repository.
+
+Input
+  - ctx: A context to control lifecycle.
+  - senzingSettings: Senzing engine settings, as JSON.
+
+Output
+  - The path.  ErrUnsupportedDatabase unless the repository is a single SQLite database file.
+*/
+func DatabaseFile(ctx context.Context, senzingSettings string) (string, error) {
+	parsedSenzingSettings, err := settingsparser.New(senzingSettings)
+	if err != nil {
+		return "", err
+	}
+	databaseURLs, err := parsedSenzingSettings.GetDatabaseURLs(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(databaseURLs) != 1 {
+		return "", fmt.Errorf("%w: found %d databases", ErrUnsupportedDatabase, len(databaseURLs))
+	}
+	parsedDatabaseURL, err := dbhelper.ParseDatabaseURL(databaseURLs[0])
+	if err != nil {
+		return "", err
+	}
+	if parsedDatabaseURL.Scheme != "sqlite3" {
+		return "", fmt.Errorf("%w: found a %s database", ErrUnsupportedDatabase, parsedDatabaseURL.Scheme)
+	}
+	if parsedDatabaseURL.Query().Get("mode") == "memory" || len(parsedDatabaseURL.Path) == 0 {
+		return "", fmt.Errorf("%w: found an in-memory database", ErrUnsupportedDatabase)
+	}
+	return parsedDatabaseURL.Path, nil
+}
+
+// The DefaultDirectory function returns where snapshots of a SQLite database file are saved by default:
+// the "snapshots" directory beside it.
+func DefaultDirectory(databaseFile string) string {
+	return filepath.Join(filepath.Dir(databaseFile), "snapshots")
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// The file the baseline is saved in.  Its name is reserved, so no snapshot has it.
+func (snapshots *BasicSnapshots) getBaselineFile() string {
+	return filepath.Join(snapshots.Directory, BaselineName+fileExtension)
+}
+
+// The file a snapshot is saved in.
+func (snapshots *BasicSnapshots) getFile(name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	if name == BaselineName {
+		return "", fmt.Errorf("%w: %q is reserved", ErrInvalidName, name)
+	}
+	return filepath.Join(snapshots.Directory, name+fileExtension), nil
+}
+
+// Run fn while Server holds its calls back, or just run it without a Server.
+func (snapshots *BasicSnapshots) withoutCalls(ctx context.Context, fn func(ctx context.Context) error) error {
+	if snapshots.Server == nil {
+		return fn(ctx)
+	}
+	return snapshots.Server.WithoutCalls(ctx, fn)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Copy the SQLite database in source over the one in destination, which is created if missing.
+func backup(ctx context.Context, destination string, source string) error {
+	sourceConnection, err := openSQLite(source, "ro")
+	if err != nil {
+		return err
+	}
+	defer sourceConnection.Close()
+	destinationConnection, err := openSQLite(destination, "rwc")
+	if err != nil {
+		return err
+	}
+	defer destinationConnection.Close()
+	aBackup, err := destinationConnection.Backup("main", sourceConnection, "main")
+	if err != nil {
+		return err
+	}
+	for {
+		isDone, err := aBackup.Step(-1)
+		if err != nil || isDone {
+			finishErr := aBackup.Finish()
+			if err != nil {
+				return err
+			}
+			return finishErr
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), aBackup.Finish())
+		case <-time.After(busyRetryInterval):
+		}
+	}
+}
+
+// Describe the snapshot saved in a file.
+func describe(file string) (Snapshot, error) {
+	fileInfo, err := os.Stat(file)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return Snapshot{
+		Name: strings.TrimSuffix(filepath.Base(file), fileExtension),
+		Size: fileInfo.Size(),
+		Time: fileInfo.ModTime(),
+	}, nil
+}
+
+// Open a SQLite database file, in mode "ro", "rw", or "rwc".
+func openSQLite(file string, mode string) (*sqlite3.SQLiteConn, error) {
+	connection, err := (&sqlite3.SQLiteDriver{}).Open("file:" + file + "?mode=" + mode)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", file, err)
+	}
+	return connection.(*sqlite3.SQLiteConn), nil
+}
@@ -0,0 +1,5 @@
+/*
+Package supervisor starts child processes, restarts them with backoff when they exit,
+and reports their state.
+*/
+package supervisor
@@ -0,0 +1,86 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The Supervisor interface...
+type Supervisor interface {
+	Restart(ctx context.Context) error
+	Serve(ctx context.Context) error
+	Status() []ProgramStatus
+}
+
+// Program describes a child process to supervise.
+type Program struct {
+	Arguments   []string `json:"arguments,omitempty"`
+	Command     string   `json:"command"`
+	Directory   string   `json:"directory,omitempty"`
+	Environment []string `json:"environment,omitempty"`
+	Name        string   `json:"name"`
+}
+
+// ProgramStatus reports the state of a supervised Program.
+type ProgramStatus struct {
+	ExitStatus string    `json:"exitStatus,omitempty"`
+	Name       string    `json:"name"`
+	PID        int       `json:"pid,omitempty"`
+	Restarts   int       `json:"restarts"`
+	Since      time.Time `json:"since"`
+	State      string    `json:"state"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Identfier of the  package found messages having the format "senzing-6212xxxx".
+const ComponentID = 6212
+
+// Default delay before the first restart.  Each further restart doubles it.
+const DefaultBackoffInitial = time.Second
+
+// Default longest delay before a restart.
+const DefaultBackoffMax = time.Minute
+
+// Program states.
+const (
+	StateBackoff  = "backoff"
+	StateRunning  = "running"
+	StateStarting = "starting"
+	StateStopped  = "stopped"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrDuplicateName is returned by Serve when two programs have the same Name.
+var ErrDuplicateName = errors.New("supervisor: program names must be unique")
+
+// ErrMissingCommand is returned by Serve when a program has no Command.
+var ErrMissingCommand = errors.New("supervisor: program has no command")
+
+// ErrMissingName is returned by Serve when a program has no Name.
+var ErrMissingName = errors.New("supervisor: program has no name")
+
+// Message templates.
+var IDMessages = map[int]string{
+	2001: "Starting program %s: %s %v",
+	2002: "Program %s started with PID %d.",
+	2003: "Program %s exited (%s). Restarting in %v.",
+	2004: "Stopping program %s.",
+	2005: "Program %s stopped.",
+	2006: "Restarting program %s.",
+	4001: "Could not start program %s. Retrying in %v.",
+	4002: "Could not read programs file %s.",
+}
+
+// Status strings for specific messages.
+var IDStatuses = map[int]string{}
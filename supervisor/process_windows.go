@@ -0,0 +1,17 @@
+//go:build windows
+
+package supervisor
+
+import (
+	"os/exec"
+)
+
+// Windows has no process groups to signal.
+func setProcessGroup(cmd *exec.Cmd) {
+	_ = cmd
+}
+
+// Windows cannot deliver SIGTERM, so kill the program.
+func terminate(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,372 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-logging/logging"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// BasicSupervisor is the default implementation of the Supervisor interface.
+type BasicSupervisor struct {
+	BackoffInitial     time.Duration
+	BackoffMax         time.Duration
+	commands           []*exec.Cmd
+	isRestartRequested []bool
+	logger             logging.Logging
+	mutex              sync.Mutex
+	Programs           []Program
+	ShutdownTimeout    time.Duration
+	statuses           []ProgramStatus
+}
+
+// programsFile is the format of the file read by LoadPrograms.
+type programsFile struct {
+	Programs []Program `json:"programs"`
+}
+
+// lineWriter prefixes each line written to it before passing it on.
+type lineWriter struct {
+	buffer []byte
+	mutex  sync.Mutex
+	out    io.Writer
+	prefix string
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Serve method starts every program and blocks until ctx is cancelled.
+A program that exits, or cannot be started, is restarted after a delay that
+starts at BackoffInitial and doubles up to BackoffMax.  A program that ran
+for at least BackoffMax is restarted after BackoffInitial again.
+Each line of a program's output is passed to standard output or standard
+error, prefixed with the program's name.
+When ctx is cancelled, each program and the processes it started are sent SIGTERM and given up to
+ShutdownTimeout to exit before it is killed.  A ShutdownTimeout of zero waits indefinitely.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - nil once every program has stopped, or an error if Programs is invalid.
+*/
+func (supervisor *BasicSupervisor) Serve(ctx context.Context) error {
+	err := supervisor.validate()
+	if err != nil {
+		return err
+	}
+
+	supervisor.mutex.Lock()
+	supervisor.commands = make([]*exec.Cmd, len(supervisor.Programs))
+	supervisor.isRestartRequested = make([]bool, len(supervisor.Programs))
+	supervisor.statuses = make([]ProgramStatus, len(supervisor.Programs))
+	for index, program := range supervisor.Programs {
+		supervisor.statuses[index] = ProgramStatus{
+			Name:  program.Name,
+			Since: time.Now(),
+			State: StateStarting,
+		}
+	}
+	supervisor.mutex.Unlock()
+
+	var waitGroup sync.WaitGroup
+	for index, program := range supervisor.Programs {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			supervisor.supervise(ctx, index, program)
+		}()
+	}
+	waitGroup.Wait()
+	return nil
+}
+
+/*
+The Restart method stops each running program, as when ctx of Serve is cancelled,
+and has it started again at once rather than after a delay.
+It returns without waiting for the programs to start.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - An error if a program could not be sent SIGTERM.
+*/
+func (supervisor *BasicSupervisor) Restart(ctx context.Context) error {
+	_ = ctx
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	var err error
+	for index, cmd := range supervisor.commands {
+		if cmd == nil {
+			continue
+		}
+		supervisor.log(2006, supervisor.Programs[index].Name)
+		supervisor.isRestartRequested[index] = true
+		err = errors.Join(err, terminate(cmd))
+	}
+	return err
+}
+
+/*
+The Status method reports the state of each program, in the order of Programs.
+
+Output
+  - A snapshot of each program's state.  Empty until Serve is called.
+*/
+func (supervisor *BasicSupervisor) Status() []ProgramStatus {
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	return append([]ProgramStatus{}, supervisor.statuses...)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LoadPrograms function reads program definitions from a JSON file.
+Example:
+
+	{"programs": [{"name": "notebook", "command": "jupyter", "arguments": ["notebook"], "directory": "/tmp"}]}
+
+Input
+  - filename: The path of the JSON file.
+
+Output
+  - The programs defined in the file.
+*/
+func LoadPrograms(filename string) ([]Program, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	result := programsFile{}
+	err = json.Unmarshal(contents, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return result.Programs, nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+// --- Logging ----------------------------------------------------------------
+
+// Get the Logger singleton.
+func (supervisor *BasicSupervisor) getLogger() logging.Logging {
+	var err error
+	if supervisor.logger == nil {
+		options := []interface{}{
+			logging.OptionCallerSkip{Value: 3},
+			logging.OptionMessageFields{Value: []string{"id", "text", "reason", "errors", "details"}},
+		}
+		supervisor.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, options...)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return supervisor.logger
+}
+
+// Log message.
+func (supervisor *BasicSupervisor) log(messageNumber int, details ...interface{}) {
+	supervisor.getLogger().Log(messageNumber, details...)
+}
+
+// --- Programs ---------------------------------------------------------------
+
+// Run a program until ctx is cancelled, restarting it with backoff.
+func (supervisor *BasicSupervisor) supervise(ctx context.Context, index int, program Program) {
+	backoffInitial, backoffMax := supervisor.getBackoffs()
+	backoff := backoffInitial
+	stdout := &lineWriter{out: os.Stdout, prefix: fmt.Sprintf("[%s] ", program.Name)}
+	stderr := &lineWriter{out: os.Stderr, prefix: fmt.Sprintf("[%s] ", program.Name)}
+	defer stdout.flush()
+	defer stderr.flush()
+
+	for restarts := 0; ; restarts++ {
+		supervisor.setStatus(index, func(status *ProgramStatus) {
+			status.PID = 0
+			status.Restarts = restarts
+			status.State = StateStarting
+		})
+		supervisor.log(2001, program.Name, program.Command, program.Arguments)
+		started := time.Now()
+		exitStatus, err := supervisor.run(ctx, index, program, stdout, stderr)
+		if ctx.Err() != nil {
+			supervisor.log(2005, program.Name)
+			supervisor.setStatus(index, func(status *ProgramStatus) {
+				status.ExitStatus = exitStatus
+				status.PID = 0
+				status.State = StateStopped
+			})
+			return
+		}
+
+		if supervisor.takeRestartRequest(index) {
+			continue
+		}
+		if time.Since(started) >= backoffMax {
+			backoff = backoffInitial
+		}
+		if err != nil {
+			supervisor.log(4001, program.Name, backoff.String(), err)
+		} else {
+			supervisor.log(2003, program.Name, exitStatus, backoff.String())
+		}
+		supervisor.setStatus(index, func(status *ProgramStatus) {
+			status.ExitStatus = exitStatus
+			status.PID = 0
+			status.State = StateBackoff
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			supervisor.setStatus(index, func(status *ProgramStatus) {
+				status.State = StateStopped
+			})
+			return
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, backoffMax)
+	}
+}
+
+// Run a program once.  Return a description of how it exited,
+// or an error if it could not be started.
+func (supervisor *BasicSupervisor) run(ctx context.Context, index int, program Program, stdout io.Writer, stderr io.Writer) (string, error) {
+	cmd := exec.CommandContext(ctx, program.Command, program.Arguments...) //nolint:gosec
+	cmd.Dir = program.Directory
+	cmd.Env = append(os.Environ(), program.Environment...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		supervisor.log(2004, program.Name)
+		return terminate(cmd)
+	}
+	cmd.WaitDelay = supervisor.ShutdownTimeout
+
+	err := cmd.Start()
+	if err != nil {
+		return err.Error(), err
+	}
+	supervisor.log(2002, program.Name, cmd.Process.Pid)
+	supervisor.setCommand(index, cmd)
+	defer supervisor.setCommand(index, nil)
+	supervisor.setStatus(index, func(status *ProgramStatus) {
+		status.PID = cmd.Process.Pid
+		status.Since = time.Now()
+		status.State = StateRunning
+	})
+
+	err = cmd.Wait()
+	supervisor.setStatus(index, func(status *ProgramStatus) {
+		status.Since = time.Now()
+	})
+	if cmd.ProcessState != nil {
+		return cmd.ProcessState.String(), nil
+	}
+	return err.Error(), nil
+}
+
+func (supervisor *BasicSupervisor) getBackoffs() (time.Duration, time.Duration) {
+	backoffInitial := supervisor.BackoffInitial
+	if backoffInitial <= 0 {
+		backoffInitial = DefaultBackoffInitial
+	}
+	backoffMax := supervisor.BackoffMax
+	if backoffMax <= 0 {
+		backoffMax = DefaultBackoffMax
+	}
+	return backoffInitial, max(backoffInitial, backoffMax)
+}
+
+// Remember the running command of a program, or nil once it has exited, for Restart.
+func (supervisor *BasicSupervisor) setCommand(index int, cmd *exec.Cmd) {
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	supervisor.commands[index] = cmd
+}
+
+func (supervisor *BasicSupervisor) setStatus(index int, update func(status *ProgramStatus)) {
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	update(&supervisor.statuses[index])
+}
+
+// Whether Restart stopped a program, clearing the request.
+func (supervisor *BasicSupervisor) takeRestartRequest(index int) bool {
+	supervisor.mutex.Lock()
+	defer supervisor.mutex.Unlock()
+	result := supervisor.isRestartRequested[index]
+	supervisor.isRestartRequested[index] = false
+	return result
+}
+
+func (supervisor *BasicSupervisor) validate() error {
+	names := map[string]bool{}
+	for _, program := range supervisor.Programs {
+		switch {
+		case len(program.Name) == 0:
+			return ErrMissingName
+		case len(program.Command) == 0:
+			return fmt.Errorf("%s: %w", program.Name, ErrMissingCommand)
+		case names[program.Name]:
+			return fmt.Errorf("%s: %w", program.Name, ErrDuplicateName)
+		}
+		names[program.Name] = true
+	}
+	return nil
+}
+
+// --- Output -----------------------------------------------------------------
+
+// Write each complete line with the prefix.  Hold a partial line until it is completed or flushed.
+func (writer *lineWriter) Write(data []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	writer.buffer = append(writer.buffer, data...)
+	for {
+		end := bytes.IndexByte(writer.buffer, '\n')
+		if end < 0 {
+			break
+		}
+		_, err := fmt.Fprintf(writer.out, "%s%s", writer.prefix, writer.buffer[:end+1])
+		writer.buffer = writer.buffer[end+1:]
+		if err != nil {
+			return len(data), err
+		}
+	}
+	return len(data), nil
+}
+
+// Write any partial line.
+func (writer *lineWriter) flush() {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	if len(writer.buffer) > 0 {
+		_, _ = fmt.Fprintf(writer.out, "%s%s\n", writer.prefix, writer.buffer)
+		writer.buffer = nil
+	}
+}
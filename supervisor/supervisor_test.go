@@ -0,0 +1,164 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicSupervisor_Serve(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	supervisor := getTestObject(test)
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- supervisor.Serve(ctx)
+	}()
+	require.Eventually(test, func() bool {
+		status := supervisor.Status()
+		return len(status) == 2 && status[0].State == StateRunning && status[0].PID > 0 && status[1].Restarts > 1
+	}, 5*time.Second, 10*time.Millisecond)
+	stopping := time.Now()
+	cancel()
+	require.NoError(test, <-serveErrors)
+	assert.Less(test, time.Since(stopping), supervisor.ShutdownTimeout, "sleep, started by sh, should be stopped too")
+	for _, status := range supervisor.Status() {
+		assert.Equal(test, StateStopped, status.State)
+		assert.Zero(test, status.PID)
+	}
+}
+
+func TestBasicSupervisor_Restart(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	supervisor := getTestObject(test)
+	supervisor.BackoffInitial = time.Minute
+	supervisor.BackoffMax = time.Minute
+	supervisor.Programs = supervisor.Programs[:1]
+	require.NoError(test, supervisor.Restart(ctx), "nothing running yet")
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- supervisor.Serve(ctx)
+	}()
+	require.Eventually(test, func() bool {
+		return supervisor.Status()[0].State == StateRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	pid := supervisor.Status()[0].PID
+	require.NoError(test, supervisor.Restart(ctx))
+	require.Eventually(test, func() bool {
+		status := supervisor.Status()[0]
+		return status.State == StateRunning && status.PID != pid && status.Restarts == 1
+	}, 5*time.Second, 10*time.Millisecond, "restarted at once, not after BackoffInitial")
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicSupervisor_Serve_commandNotFound(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	supervisor := getTestObject(test)
+	supervisor.Programs = []Program{{Name: "missing", Command: filepath.Join(test.TempDir(), "missing")}}
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- supervisor.Serve(ctx)
+	}()
+	require.Eventually(test, func() bool {
+		status := supervisor.Status()
+		return len(status) == 1 && status[0].State == StateBackoff && len(status[0].ExitStatus) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(test, <-serveErrors)
+}
+
+func TestBasicSupervisor_Serve_noPrograms(test *testing.T) {
+	ctx := context.TODO()
+	supervisor := &BasicSupervisor{}
+	require.NoError(test, supervisor.Serve(ctx))
+	assert.Empty(test, supervisor.Status())
+}
+
+func TestBasicSupervisor_Serve_invalidPrograms(test *testing.T) {
+	ctx := context.TODO()
+	testCases := []struct {
+		name     string
+		programs []Program
+		expected error
+	}{
+		{name: "duplicate name", programs: []Program{{Name: "a", Command: "true"}, {Name: "a", Command: "true"}}, expected: ErrDuplicateName},
+		{name: "missing command", programs: []Program{{Name: "a"}}, expected: ErrMissingCommand},
+		{name: "missing name", programs: []Program{{Command: "true"}}, expected: ErrMissingName},
+	}
+	for _, testCase := range testCases {
+		test.Run(testCase.name, func(test *testing.T) {
+			supervisor := &BasicSupervisor{Programs: testCase.programs}
+			require.ErrorIs(test, supervisor.Serve(ctx), testCase.expected)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestLoadPrograms(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "programs.json")
+	contents := `{"programs": [{"name": "notebook", "command": "jupyter", "arguments": ["notebook"], "directory": "/tmp", "environment": ["A=1"]}]}`
+	require.NoError(test, os.WriteFile(filename, []byte(contents), 0600))
+	programs, err := LoadPrograms(filename)
+	require.NoError(test, err)
+	expected := []Program{{Name: "notebook", Command: "jupyter", Arguments: []string{"notebook"}, Directory: "/tmp", Environment: []string{"A=1"}}}
+	assert.Equal(test, expected, programs)
+}
+
+func TestLoadPrograms_badFile(test *testing.T) {
+	filename := filepath.Join(test.TempDir(), "programs.json")
+	_, err := LoadPrograms(filename)
+	require.Error(test, err)
+	require.NoError(test, os.WriteFile(filename, []byte("{"), 0600))
+	_, err = LoadPrograms(filename)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func TestLineWriter_Write(test *testing.T) {
+	var out bytes.Buffer
+	writer := &lineWriter{out: &out, prefix: "[test] "}
+	_, err := writer.Write([]byte("one\ntw"))
+	require.NoError(test, err)
+	_, err = writer.Write([]byte("o\nthree"))
+	require.NoError(test, err)
+	assert.Equal(test, "[test] one\n[test] two\n", out.String())
+	writer.flush()
+	assert.Equal(test, "[test] one\n[test] two\n[test] three\n", out.String())
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// A long-running program that starts a process of its own, and a program that exits at once.
+func getTestObject(test *testing.T) *BasicSupervisor {
+	_ = test
+	return &BasicSupervisor{
+		BackoffInitial: 10 * time.Millisecond,
+		BackoffMax:     20 * time.Millisecond,
+		Programs: []Program{
+			{Name: "sleeper", Command: "sh", Arguments: []string{"-c", "sleep 60; exit 0"}},
+			{Name: "quitter", Command: "sh", Arguments: []string{"-c", "echo quitting; exit 3"}},
+		},
+		ShutdownTimeout: 5 * time.Second,
+	}
+}
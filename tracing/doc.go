@@ -0,0 +1,6 @@
+/*
+Package tracing records OpenTelemetry traces of the playground's HTTP requests,
+reverse-proxied requests, and gRPC calls, and exports them to an OTLP collector,
+to stdout, or to a file.
+*/
+package tracing
@@ -0,0 +1,29 @@
+package tracing
+
+import "errors"
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Exporters of traces.
+const (
+	ExporterFile   = "file"
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Value of OTEL_EXPORTER_OTLP_PROTOCOL, or OTEL_EXPORTER_OTLP_TRACES_PROTOCOL,
+// that selects OTLP over HTTP.  Otherwise OTLP is sent over gRPC.
+const ProtocolHTTP = "http/protobuf"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrMissingFile is returned by Start when Exporter is ExporterFile but File is not set.
+var ErrMissingFile = errors.New("tracing: no file to export traces to")
+
+// ErrUnknownExporter is returned by Start when Exporter is not one of the Exporter constants.
+var ErrUnknownExporter = errors.New("tracing: unknown exporter")
@@ -0,0 +1,241 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+BasicTracing records the playground's traces with a tracer provider of its own.
+Until Start is called, or if Exporter is ExporterNone, nothing is traced.
+Every method of a nil *BasicTracing is a no-op that returns its input unchanged,
+so tracing can be disabled by passing nil.
+*/
+type BasicTracing struct {
+	Exporter    string
+	File        string
+	output      io.Closer
+	propagator  propagation.TextMapPropagator
+	provider    *sdktrace.TracerProvider
+	ServiceName string
+	Version     string
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The GrpcDialOptions method returns options that trace each call a gRPC client makes,
+and propagate the trace to the server.
+
+Output
+  - Options for grpc.NewClient.
+*/
+func (tracing *BasicTracing) GrpcDialOptions() []grpc.DialOption {
+	if !tracing.isStarted() {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithPropagators(tracing.propagator),
+			otelgrpc.WithTracerProvider(tracing.provider),
+		)),
+	}
+}
+
+/*
+The GrpcServerOptions method returns options that trace each call a gRPC server handles,
+continuing the trace propagated by the client.
+
+Output
+  - Options for grpc.NewServer.
+*/
+func (tracing *BasicTracing) GrpcServerOptions() []grpc.ServerOption {
+	if !tracing.isStarted() {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithPropagators(tracing.propagator),
+			otelgrpc.WithTracerProvider(tracing.provider),
+		)),
+	}
+}
+
+/*
+The InstrumentHandler method traces each request a handler serves,
+continuing the trace propagated by the client, if any.
+Spans are named by method and route.  Example: "GET /api/".
+
+Input
+  - handler: The handler to instrument.
+  - route: Returns the route a request matches, or "" for none.  Example: the pattern from http.ServeMux.Handler.
+
+Output
+  - The instrumented handler.
+*/
+func (tracing *BasicTracing) InstrumentHandler(handler http.Handler, route func(r *http.Request) string) http.Handler {
+	if !tracing.isStarted() {
+		return handler
+	}
+	return otelhttp.NewHandler(handler, "HTTP",
+		otelhttp.WithPropagators(tracing.propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			routeName := route(r)
+			if len(routeName) == 0 {
+				return r.Method
+			}
+			return r.Method + " " + routeName
+		}),
+		otelhttp.WithTracerProvider(tracing.provider),
+	)
+}
+
+/*
+The InstrumentTransport method traces the requests a reverse proxy sends upstream,
+and propagates the trace to the upstream service.
+
+Input
+  - upstream: Name of the upstream service, used in span names.  Example: "jupyter-lab".
+  - transport: The transport to instrument.  If nil, http.DefaultTransport.
+
+Output
+  - The instrumented transport.
+*/
+func (tracing *BasicTracing) InstrumentTransport(upstream string, transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if !tracing.isStarted() {
+		return transport
+	}
+	return otelhttp.NewTransport(transport,
+		otelhttp.WithPropagators(tracing.propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return fmt.Sprintf("%s %s", r.Method, upstream)
+		}),
+		otelhttp.WithTracerProvider(tracing.provider),
+	)
+}
+
+/*
+The Shutdown method exports the traces not yet exported and stops tracing.
+
+Input
+  - ctx: A context to bound the time taken to export.
+
+Output
+  - An error if traces could not be exported.
+*/
+func (tracing *BasicTracing) Shutdown(ctx context.Context) error {
+	if !tracing.isStarted() {
+		return nil
+	}
+	err := tracing.provider.Shutdown(ctx)
+	if tracing.output != nil {
+		err = errors.Join(err, tracing.output.Close())
+	}
+	return err
+}
+
+/*
+The Start method creates the exporter selected by Exporter and starts tracing.
+ExporterOTLP is configured by the standard OTEL_EXPORTER_OTLP_* environment variables,
+and sampling by OTEL_TRACES_SAMPLER.  ExporterFile appends to File, one JSON span per line.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - An error if the exporter could not be created.
+*/
+func (tracing *BasicTracing) Start(ctx context.Context) error {
+	if tracing == nil {
+		return nil
+	}
+	exporter, err := tracing.newExporter(ctx)
+	if err != nil || exporter == nil {
+		return err
+	}
+	traceResource, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(tracing.ServiceName),
+			semconv.ServiceVersion(tracing.Version),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return err
+	}
+	tracing.propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	tracing.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(traceResource),
+	)
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Internal methods
+// ----------------------------------------------------------------------------
+
+func (tracing *BasicTracing) isStarted() bool {
+	return tracing != nil && tracing.provider != nil
+}
+
+// The exporter selected by Exporter.  None for ExporterNone.
+func (tracing *BasicTracing) newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	switch tracing.Exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterFile:
+		if len(tracing.File) == 0 {
+			return nil, ErrMissingFile
+		}
+		file, err := os.OpenFile(tracing.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		tracing.output = file
+		return exporter, nil
+	case ExporterOTLP:
+		protocol, isSet := os.LookupEnv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+		if !isSet {
+			protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+		}
+		if protocol == ProtocolHTTP {
+			return otlptracehttp.New(ctx)
+		}
+		return otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, tracing.Exporter)
+	}
+}
@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+// A request traced through a reverse proxy and a gRPC call is one trace.
+func TestBasicTracing_Start_file(test *testing.T) {
+	ctx := context.TODO()
+	filename := filepath.Join(test.TempDir(), "traces.json")
+	tracing := &BasicTracing{
+		Exporter:    ExporterFile,
+		File:        filename,
+		ServiceName: "test",
+	}
+	require.NoError(test, tracing.Start(ctx))
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(tracing.GrpcServerOptions()...)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, tracing.GrpcDialOptions()...)
+	connection, err := grpc.NewClient("passthrough:///in-process", dialOptions...)
+	require.NoError(test, err)
+	defer connection.Close()
+
+	var upstreamTraceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamTraceparent = r.Header.Get("Traceparent")
+	}))
+	defer upstream.Close()
+	client := &http.Client{Transport: tracing.InstrumentTransport("upstream", nil)}
+
+	handler := tracing.InstrumentHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := grpc_health_v1.NewHealthClient(connection).Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(test, err)
+		request, err := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		require.NoError(test, err)
+		response, err := client.Do(request)
+		require.NoError(test, err)
+		response.Body.Close()
+	}), func(r *http.Request) string { return "/search/" })
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search/x", nil))
+	require.NoError(test, tracing.Shutdown(ctx))
+
+	spans := readSpans(test, filename)
+	require.Len(test, spans, 4)
+	traceIDs := map[string]bool{}
+	names := []string{}
+	for _, span := range spans {
+		traceIDs[span.SpanContext.TraceID] = true
+		names = append(names, span.Name)
+	}
+	assert.Len(test, traceIDs, 1)
+	assert.ElementsMatch(test, []string{"GET /search/", "grpc.health.v1.Health/Check", "grpc.health.v1.Health/Check", "GET upstream"}, names)
+	assert.Contains(test, upstreamTraceparent, spans[0].SpanContext.TraceID)
+}
+
+func TestBasicTracing_Start_invalid(test *testing.T) {
+	ctx := context.TODO()
+	require.ErrorIs(test, (&BasicTracing{Exporter: "bad"}).Start(ctx), ErrUnknownExporter)
+	require.ErrorIs(test, (&BasicTracing{Exporter: ExporterFile}).Start(ctx), ErrMissingFile)
+	require.Error(test, (&BasicTracing{Exporter: ExporterFile, File: test.TempDir()}).Start(ctx))
+}
+
+func TestBasicTracing_none(test *testing.T) {
+	ctx := context.TODO()
+	handler := http.NotFoundHandler()
+	for _, tracing := range []*BasicTracing{nil, {}, {Exporter: ExporterNone}} {
+		require.NoError(test, tracing.Start(ctx))
+		assert.Nil(test, tracing.GrpcDialOptions())
+		assert.Nil(test, tracing.GrpcServerOptions())
+		assert.NotNil(test, tracing.InstrumentHandler(handler, nil))
+		assert.Equal(test, http.DefaultTransport, tracing.InstrumentTransport("test", nil))
+		require.NoError(test, tracing.Shutdown(ctx))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+type testSpan struct {
+	Name        string `json:"Name"`
+	SpanContext struct {
+		TraceID string `json:"TraceID"`
+	} `json:"SpanContext"`
+}
+
+// Spans in a file written by ExporterFile.
+func readSpans(test *testing.T, filename string) []testSpan {
+	file, err := os.Open(filename)
+	require.NoError(test, err)
+	defer file.Close()
+	result := []testSpan{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		span := testSpan{}
+		require.NoError(test, json.Unmarshal(scanner.Bytes(), &span))
+		result = append(result, span)
+	}
+	require.NoError(test, scanner.Err())
+	return result
+}